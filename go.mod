@@ -3,9 +3,11 @@ module github.com/newrelic/terraform-provider-newrelic/v2
 go 1.15
 
 require (
+	github.com/hashicorp/go-retryablehttp v0.6.8
 	github.com/hashicorp/terraform-plugin-sdk v1.16.0
 	github.com/newrelic/go-agent/v3 v3.10.0
 	github.com/newrelic/go-insights v1.0.3
 	github.com/newrelic/newrelic-client-go v0.56.1
 	github.com/stretchr/testify v1.7.0
+	golang.org/x/net v0.0.0-20200707034311-ab3426394381
 )
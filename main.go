@@ -1,6 +1,14 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-sdk/plugin"
 
 	"github.com/newrelic/terraform-provider-newrelic/v2/newrelic"
@@ -17,6 +25,142 @@ func main() {
 	// to ensure it gets properly set as part of the user agent header.
 	newrelic.ProviderVersion = ProviderVersion
 
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := runExport(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %s\n", err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: newrelic.Provider})
 }
+
+// runExport writes the configuration of an existing account to HCL, along with
+// a script of matching `terraform import` commands. Credentials and connection
+// settings default to the same environment variables the provider reads.
+func runExport(args []string) error {
+	accountID, _ := strconv.Atoi(os.Getenv("NEW_RELIC_ACCOUNT_ID"))
+	insecureSkipVerify, _ := strconv.ParseBool(os.Getenv("NEW_RELIC_API_SKIP_VERIFY"))
+	retryIdempotentOnly, _ := strconv.ParseBool(os.Getenv("NEW_RELIC_RETRY_IDEMPOTENT_ONLY"))
+
+	maxRetries := 3
+	if v, err := strconv.Atoi(os.Getenv("NEW_RELIC_MAX_RETRIES")); err == nil {
+		maxRetries = v
+	}
+
+	retryWaitMin := 1
+	if v, err := strconv.Atoi(os.Getenv("NEW_RELIC_RETRY_WAIT_MIN")); err == nil {
+		retryWaitMin = v
+	}
+
+	retryWaitMax := 30
+	if v, err := strconv.Atoi(os.Getenv("NEW_RELIC_RETRY_WAIT_MAX")); err == nil {
+		retryWaitMax = v
+	}
+
+	region := os.Getenv("NEW_RELIC_REGION")
+	if region == "" {
+		region = "US"
+	}
+
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.IntVar(&accountID, "account-id", accountID, "The New Relic account ID to export. Defaults to NEW_RELIC_ACCOUNT_ID.")
+	apiKey := flags.String("api-key", os.Getenv("NEW_RELIC_API_KEY"), "A New Relic User API key. Defaults to NEW_RELIC_API_KEY.")
+	adminAPIKey := flags.String("admin-api-key", os.Getenv("NEW_RELIC_ADMIN_API_KEY"), "A New Relic Admin API key. Defaults to NEW_RELIC_ADMIN_API_KEY.")
+	flags.StringVar(&region, "region", region, "The region of the account, US or EU. Defaults to NEW_RELIC_REGION.")
+	apiURL := flags.String("api-url", os.Getenv("NEW_RELIC_API_URL"), "Override the REST API URL.")
+	nerdGraphAPIURL := flags.String("nerdgraph-api-url", os.Getenv("NEW_RELIC_NERDGRAPH_API_URL"), "Override the NerdGraph API URL.")
+	cacertFile := flags.String("cacert-file", os.Getenv("NEW_RELIC_API_CACERT"), "A PEM file of CA certificates to trust. Defaults to NEW_RELIC_API_CACERT.")
+	clientCertFile := flags.String("client-cert-file", os.Getenv("NEW_RELIC_CLIENT_CERT"), "A PEM client certificate file. Defaults to NEW_RELIC_CLIENT_CERT.")
+	clientKeyFile := flags.String("client-key-file", os.Getenv("NEW_RELIC_CLIENT_KEY"), "The PEM private key file of the client certificate. Defaults to NEW_RELIC_CLIENT_KEY.")
+	flags.BoolVar(&insecureSkipVerify, "insecure-skip-verify", insecureSkipVerify, "Skip the verification of TLS certificates. Defaults to NEW_RELIC_API_SKIP_VERIFY.")
+	proxyURL := flags.String("proxy-url", os.Getenv("NEW_RELIC_PROXY_URL"), "The URL of a proxy for the API requests. Defaults to NEW_RELIC_PROXY_URL.")
+	noProxy := flags.String("no-proxy", os.Getenv("NEW_RELIC_NO_PROXY"), "The hosts reached without the proxy. Defaults to NEW_RELIC_NO_PROXY.")
+	flags.IntVar(&maxRetries, "max-retries", maxRetries, "The maximum number of times a rate limited or failed API request is retried. Defaults to NEW_RELIC_MAX_RETRIES.")
+	flags.BoolVar(&retryIdempotentOnly, "retry-idempotent-only", retryIdempotentOnly, "Only retry the requests that are safe to send twice after a server error. Defaults to NEW_RELIC_RETRY_IDEMPOTENT_ONLY.")
+	flags.IntVar(&retryWaitMin, "retry-wait-min", retryWaitMin, "The minimum time to wait between retries, in seconds. Defaults to NEW_RELIC_RETRY_WAIT_MIN.")
+	flags.IntVar(&retryWaitMax, "retry-wait-max", retryWaitMax, "The maximum time to wait between retries, in seconds. Defaults to NEW_RELIC_RETRY_WAIT_MAX.")
+	out := flags.String("out", "newrelic.tf", "The file the generated HCL is written to.")
+	importScript := flags.String("import-script", "import.sh", "The file the generated `terraform import` commands are written to.")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	cfg := newrelic.Config{
+		AdminAPIKey:         *adminAPIKey,
+		PersonalAPIKey:      *apiKey,
+		Region:              region,
+		APIURL:              *apiURL,
+		NerdGraphAPIURL:     *nerdGraphAPIURL,
+		CACertFile:          *cacertFile,
+		ClientCertFile:      *clientCertFile,
+		ClientKeyFile:       *clientKeyFile,
+		InsecureSkipVerify:  insecureSkipVerify,
+		ProxyURL:            *proxyURL,
+		NoProxy:             *noProxy,
+		MaxRetries:          maxRetries,
+		RetryIdempotentOnly: retryIdempotentOnly,
+		RetryWaitMin:        time.Duration(retryWaitMin) * time.Second,
+		RetryWaitMax:        time.Duration(retryWaitMax) * time.Second,
+	}
+
+	if cfg.RetryWaitMin > cfg.RetryWaitMax {
+		return fmt.Errorf("-retry-wait-min (%s) must not be greater than -retry-wait-max (%s)", cfg.RetryWaitMin, cfg.RetryWaitMax)
+	}
+
+	// The export is written to temporary files, so that the files of a previous
+	// export are left as they were if it fails.
+	hclFile, err := ioutil.TempFile(filepath.Dir(*out), ".export-*.tf")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(hclFile.Name())
+	defer hclFile.Close()
+
+	importFile, err := ioutil.TempFile(filepath.Dir(*importScript), ".export-*.sh")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(importFile.Name())
+	defer importFile.Close()
+
+	opts := newrelic.ExportOptions{
+		AccountID:    accountID,
+		HCL:          hclFile,
+		ImportScript: importFile,
+	}
+
+	if err := newrelic.Export(cfg, opts); err != nil {
+		return err
+	}
+
+	if err := commitExportFile(hclFile, *out, 0644); err != nil {
+		return err
+	}
+
+	if err := commitExportFile(importFile, *importScript, 0755); err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote %s and %s\n", *out, *importScript)
+
+	return nil
+}
+
+// commitExportFile closes a temporary file of the export and renames it to its
+// destination.
+func commitExportFile(f *os.File, path string, perm os.FileMode) error {
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(f.Name(), perm); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
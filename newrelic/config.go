@@ -8,9 +8,11 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/pathorcontents"
+	"golang.org/x/net/http/httpproxy"
 
 	insights "github.com/newrelic/go-insights/client"
 	nr "github.com/newrelic/newrelic-client-go/newrelic"
@@ -25,6 +27,8 @@ type Config struct {
 	Region               string
 	APIURL               string
 	CACertFile           string
+	ClientCertFile       string
+	ClientKeyFile        string
 	InfrastructureAPIURL string
 	InsecureSkipVerify   bool
 	InsightsAccountID    string
@@ -32,7 +36,13 @@ type Config struct {
 	InsightsInsertURL    string
 	InsightsQueryKey     string
 	InsightsQueryURL     string
+	MaxRetries           int
 	NerdGraphAPIURL      string
+	NoProxy              string
+	ProxyURL             string
+	RetryIdempotentOnly  bool
+	RetryWaitMax         time.Duration
+	RetryWaitMin         time.Duration
 	SyntheticsAPIURL     string
 	userAgent            string
 }
@@ -49,32 +59,29 @@ func (c *Config) Client() (*nr.NewRelic, error) {
 		nr.ConfigRegion(c.Region),
 	)
 
-	tlsCfg := &tls.Config{}
-	var t = http.DefaultTransport
+	t, err := c.transport()
+	if err != nil {
+		return nil, err
+	}
 
-	if c.CACertFile != "" {
-		caCert, _, err := pathorcontents.Read(c.CACertFile)
-		if err != nil {
-			log.Printf("Error reading CA Cert: %s", err)
-			return nil, err
-		}
-		caCertPool := x509.NewCertPool()
-		caCertPool.AppendCertsFromPEM([]byte(caCert))
-		tlsCfg.RootCAs = caCertPool
+	var rt http.RoundTripper = t
 
-		t = &http.Transport{TLSClientConfig: tlsCfg}
-	} else if c.InsecureSkipVerify {
-		tlsCfg.InsecureSkipVerify = true
+	if logging.LogLevel() != "" {
+		options = append(options, nr.ConfigLogLevel(logging.LogLevel()))
+		rt = logging.NewTransport("newrelic", rt)
+	}
 
-		t = &http.Transport{TLSClientConfig: tlsCfg}
+	waitMin := c.RetryWaitMin
+	if waitMin <= 0 {
+		waitMin = defaultRetryWaitMin
 	}
 
-	if logging.LogLevel() != "" {
-		options = append(options, nr.ConfigLogLevel(logging.LogLevel()))
-		t = logging.NewTransport("newrelic", t)
+	waitMax := c.RetryWaitMax
+	if waitMax <= 0 {
+		waitMax = defaultRetryWaitMax
 	}
 
-	options = append(options, nr.ConfigHTTPTransport(t))
+	options = append(options, nr.ConfigHTTPTransport(newRetryTransport(rt, c.MaxRetries, waitMin, waitMax, c.RetryIdempotentOnly)))
 
 	if c.APIURL != "" {
 		options = append(options, nr.ConfigBaseURL(c.APIURL))
@@ -104,7 +111,7 @@ func (c *Config) Client() (*nr.NewRelic, error) {
 }
 
 // ClientInsightsInsert returns a new Insights insert client
-func (c *Config) ClientInsightsInsert() (*insights.InsertClient, error) {
+func (c *Config) ClientInsightsInsert() (*insightsInsertClient, error) {
 	client := insights.NewInsertClient(c.InsightsInsertKey, c.InsightsAccountID)
 
 	if c.InsightsInsertURL != "" {
@@ -124,15 +131,133 @@ func (c *Config) ClientInsightsInsert() (*insights.InsertClient, error) {
 		}
 	}
 
+	httpClient, err := c.insightsHTTPClient(client.RequestTimeout)
+	if err != nil {
+		return nil, err
+	}
+
 	log.Printf("[INFO] New Relic Insights insert client configured")
 
-	return client, nil
+	return &insightsInsertClient{InsertClient: client, httpClient: httpClient}, nil
+}
+
+// ClientInsightsQuery returns a new Insights query client
+func (c *Config) ClientInsightsQuery() (*insightsQueryClient, error) {
+	client := insights.NewQueryClient(c.InsightsQueryKey, c.InsightsAccountID)
+
+	if c.InsightsQueryURL != "" {
+		insightsURL, err := url.Parse(c.InsightsQueryURL)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing Insights URL: %q", err)
+		}
+		insightsURL.Path = fmt.Sprintf("%s/%s/query", insightsURL.Path, c.InsightsAccountID)
+		client.URL = insightsURL
+	}
+
+	if len(c.InsightsQueryKey) > 1 {
+		if err := client.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	httpClient, err := c.insightsHTTPClient(client.RequestTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[INFO] New Relic Insights query client configured")
+
+	return &insightsQueryClient{QueryClient: client, httpClient: httpClient}, nil
+}
+
+// transport returns the base HTTP transport for API requests, configured with
+// the proxy and TLS settings.
+func (c *Config) transport() (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+
+	tlsCfg := &tls.Config{}
+
+	if c.CACertFile != "" {
+		caCert, _, err := pathorcontents.Read(c.CACertFile)
+		if err != nil {
+			log.Printf("Error reading CA Cert: %s", err)
+			return nil, err
+		}
+		caCertPool := x509.NewCertPool()
+		caCertPool.AppendCertsFromPEM([]byte(caCert))
+		tlsCfg.RootCAs = caCertPool
+	} else if c.InsecureSkipVerify {
+		tlsCfg.InsecureSkipVerify = true
+	}
+
+	if c.ClientCertFile != "" || c.ClientKeyFile != "" {
+		if c.ClientCertFile == "" || c.ClientKeyFile == "" {
+			return nil, fmt.Errorf("client_cert_file and client_key_file must be configured together")
+		}
+
+		cert, _, err := pathorcontents.Read(c.ClientCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading client certificate: %w", err)
+		}
+
+		key, _, err := pathorcontents.Read(c.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading client key: %w", err)
+		}
+
+		keyPair, err := tls.X509KeyPair([]byte(cert), []byte(key))
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %w", err)
+		}
+
+		tlsCfg.Certificates = []tls.Certificate{keyPair}
+	}
+
+	t.TLSClientConfig = tlsCfg
+
+	if c.ProxyURL != "" || c.NoProxy != "" {
+		proxyCfg := httpproxy.FromEnvironment()
+
+		if c.ProxyURL != "" {
+			if _, err := url.Parse(c.ProxyURL); err != nil {
+				return nil, fmt.Errorf("error parsing proxy URL: %w", err)
+			}
+
+			proxyCfg.HTTPProxy = c.ProxyURL
+			proxyCfg.HTTPSProxy = c.ProxyURL
+		}
+
+		if c.NoProxy != "" {
+			proxyCfg.NoProxy = c.NoProxy
+		}
+
+		proxyFunc := proxyCfg.ProxyFunc()
+		t.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}
+	}
+
+	return t, nil
+}
+
+// insightsHTTPClient returns the HTTP client of the Insights requests, with
+// the proxy and TLS settings.
+func (c *Config) insightsHTTPClient(timeout time.Duration) (*http.Client, error) {
+	t, err := c.transport()
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Client{Transport: t, Timeout: timeout}, nil
 }
 
 // ProviderConfig for the custom provider
 type ProviderConfig struct {
 	NewClient            *nr.NewRelic
-	InsightsInsertClient *insights.InsertClient
+	DefaultTags          map[string]string
+	InsightsInsertClient *insightsInsertClient
+	InsightsQueryClient  *insightsQueryClient
+	InsightsQueryKey     string
 	AccountID            int
 	PersonalAPIKey       string
 }
@@ -140,3 +265,7 @@ type ProviderConfig struct {
 func (c *ProviderConfig) hasNerdGraphCredentials() bool {
 	return c.AccountID > 0 && c.PersonalAPIKey != ""
 }
+
+func (c *ProviderConfig) hasInsightsQueryCredentials() bool {
+	return c.InsightsQueryClient != nil && c.InsightsQueryKey != ""
+}
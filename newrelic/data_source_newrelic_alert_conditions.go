@@ -0,0 +1,181 @@
+package newrelic
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+)
+
+func dataSourceNewRelicAlertConditions() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNewRelicAlertConditionsRead,
+		Schema: map[string]*schema.Schema{
+			"policy_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "The ID of the policy to list the conditions of.",
+			},
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The New Relic account ID to operate on.",
+			},
+			"conditions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The conditions of the policy, across all condition types.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the condition.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the condition.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the condition.",
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the condition is enabled.",
+						},
+						"runbook_url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The runbook URL of the condition.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicAlertConditionsRead(d *schema.ResourceData, meta interface{}) error {
+	cfg := meta.(*ProviderConfig)
+
+	if !cfg.hasNerdGraphCredentials() {
+		return errors.New("err: NerdGraph support not present, but required for Read")
+	}
+
+	client := cfg.NewClient
+	accountID := selectAccountID(cfg, d)
+	policyID := d.Get("policy_id").(int)
+
+	// Only NRQL conditions are looked up in NerdGraph. The other conditions
+	// are listed with the REST API, which always reads the account of the
+	// provider.
+	if accountID != cfg.AccountID {
+		return fmt.Errorf("account_id must be the account ID of the provider (%d): conditions other than NRQL conditions are listed with the REST API, which cannot read other accounts", cfg.AccountID)
+	}
+
+	log.Printf("[INFO] Reading New Relic alert conditions of policy %d", policyID)
+
+	conditions, err := findPolicyConditions(client, accountID, policyID)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(policyID))
+
+	if err := d.Set("account_id", accountID); err != nil {
+		return err
+	}
+
+	return d.Set("conditions", conditions)
+}
+
+// findPolicyConditions lists the conditions of a policy, APM conditions first
+// and NRQL conditions last. Conditions without a type of their own are reported
+// as synthetics, synthetics_multilocation and plugins, and NRQL conditions as
+// nrql_ followed by their lowercased type, e.g. nrql_static.
+func findPolicyConditions(client *newrelic.NewRelic, accountID int, policyID int) ([]map[string]interface{}, error) {
+	conditions := []map[string]interface{}{}
+
+	add := func(id int, conditionType string, name string, enabled bool, runbookURL string) {
+		conditions = append(conditions, map[string]interface{}{
+			"id":          strconv.Itoa(id),
+			"type":        conditionType,
+			"name":        name,
+			"enabled":     enabled,
+			"runbook_url": runbookURL,
+		})
+	}
+
+	apmConditions, err := client.Alerts.ListConditions(policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range apmConditions {
+		add(c.ID, string(c.Type), c.Name, c.Enabled, c.RunbookURL)
+	}
+
+	infraConditions, err := client.Alerts.ListInfrastructureConditions(policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range infraConditions {
+		add(c.ID, c.Type, c.Name, c.Enabled, c.RunbookURL)
+	}
+
+	syntheticsConditions, err := client.Alerts.ListSyntheticsConditions(policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range syntheticsConditions {
+		add(c.ID, "synthetics", c.Name, c.Enabled, c.RunbookURL)
+	}
+
+	multiLocationConditions, err := client.Alerts.ListMultiLocationSyntheticsConditions(policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range multiLocationConditions {
+		add(c.ID, "synthetics_multilocation", c.Name, c.Enabled, c.RunbookURL)
+	}
+
+	pluginsConditions, err := client.Alerts.ListPluginsConditions(policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range pluginsConditions {
+		add(c.ID, "plugins", c.Name, c.Enabled, c.RunbookURL)
+	}
+
+	nrqlConditions, err := client.Alerts.SearchNrqlConditionsQuery(accountID, alerts.NrqlConditionsSearchCriteria{
+		PolicyID: strconv.Itoa(policyID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range nrqlConditions {
+		id, err := strconv.Atoi(c.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		add(id, "nrql_"+strings.ToLower(string(c.Type)), c.Name, c.Enabled, c.RunbookURL)
+	}
+
+	return conditions, nil
+}
@@ -0,0 +1,125 @@
+// +build unit
+
+package newrelic
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccNewRelicAlertConditionsDataSource_Offline(t *testing.T) {
+	dataSourceName := "data.newrelic_alert_conditions.foo"
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// The conditions are created before the data source reads them.
+			{
+				Config: testAccNewRelicAlertConditionsDataSourceConfigOffline(server, ""),
+			},
+			{
+				Config: testAccNewRelicAlertConditionsDataSourceConfigOffline(server, `
+data "newrelic_alert_conditions" "foo" {
+  policy_id = newrelic_alert_policy.foo.id
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "id", "newrelic_alert_policy.foo", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "conditions.#", "3"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "conditions.0.id"),
+					resource.TestCheckResourceAttr(dataSourceName, "conditions.0.type", "apm_app_metric"),
+					resource.TestCheckResourceAttr(dataSourceName, "conditions.0.name", "tf-test-apm"),
+					resource.TestCheckResourceAttr(dataSourceName, "conditions.0.enabled", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "conditions.0.runbook_url", "https://apm.example.com"),
+					resource.TestCheckResourceAttr(dataSourceName, "conditions.1.type", "infra_metric"),
+					resource.TestCheckResourceAttr(dataSourceName, "conditions.1.name", "tf-test-infra"),
+					resource.TestCheckResourceAttr(dataSourceName, "conditions.1.enabled", "false"),
+					resource.TestCheckResourceAttr(dataSourceName, "conditions.2.type", "nrql_static"),
+					resource.TestCheckResourceAttr(dataSourceName, "conditions.2.name", "tf-test-nrql"),
+					resource.TestCheckResourceAttr(dataSourceName, "conditions.2.runbook_url", "https://nrql.example.com"),
+				),
+			},
+			// Test: The REST API cannot list the conditions of another account
+			{
+				Config: testAccNewRelicAlertConditionsDataSourceConfigOffline(server, `
+data "newrelic_alert_conditions" "foo" {
+  policy_id  = newrelic_alert_policy.foo.id
+  account_id = 54321
+}
+`),
+				ExpectError: regexp.MustCompile(`account_id must be the account ID of the provider`),
+			},
+		},
+	})
+}
+
+func testAccNewRelicAlertConditionsDataSourceConfigOffline(server *testFakeServer, dataSource string) string {
+	return server.providerConfig() + `
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-policy"
+}
+
+resource "newrelic_alert_condition" "foo" {
+  policy_id = newrelic_alert_policy.foo.id
+
+  name            = "tf-test-apm"
+  type            = "apm_app_metric"
+  entities        = [12345]
+  metric          = "apdex"
+  runbook_url     = "https://apm.example.com"
+  condition_scope = "application"
+
+  term {
+    duration      = 5
+    operator      = "below"
+    priority      = "critical"
+    threshold     = 0.75
+    time_function = "all"
+  }
+}
+
+resource "newrelic_infra_alert_condition" "foo" {
+  policy_id = newrelic_alert_policy.foo.id
+
+  name       = "tf-test-infra"
+  enabled    = false
+  type       = "infra_metric"
+  event      = "StorageSample"
+  select     = "diskFreePercent"
+  comparison = "below"
+
+  critical {
+    duration      = 10
+    value         = 10
+    time_function = "any"
+  }
+}
+
+resource "newrelic_nrql_alert_condition" "foo" {
+  policy_id = newrelic_alert_policy.foo.id
+
+  name                         = "tf-test-nrql"
+  type                         = "static"
+  value_function               = "single_value"
+  runbook_url                  = "https://nrql.example.com"
+  violation_time_limit_seconds = 3600
+
+  nrql {
+    query             = "SELECT uniqueCount(hostname) FROM ComputeSample"
+    evaluation_offset = 3
+  }
+
+  critical {
+    operator              = "above"
+    threshold             = 1
+    threshold_duration    = 300
+    threshold_occurrences = "ALL"
+  }
+}
+` + dataSource
+}
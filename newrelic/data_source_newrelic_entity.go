@@ -3,6 +3,7 @@ package newrelic
 import (
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -61,8 +62,9 @@ func dataSourceNewRelicEntity() *schema.Resource {
 			},
 			"account_id": {
 				Type:        schema.TypeInt,
+				Optional:    true,
 				Computed:    true,
-				Description: "The New Relic account ID associated with this entity.",
+				Description: "The New Relic account ID associated with this entity. If specified, only entities in this account are searched.",
 			},
 			"application_id": {
 				Type:        schema.TypeInt,
@@ -92,6 +94,14 @@ func dataSourceNewRelicEntityRead(d *schema.ResourceData, meta interface{}) erro
 	entityType := entities.EntitySearchQueryBuilderType(strings.ToUpper(d.Get("type").(string)))
 	tags := expandEntityTag(d.Get("tag").([]interface{}))
 	domain := entities.EntitySearchQueryBuilderDomain(strings.ToUpper(d.Get("domain").(string)))
+	accountID := d.Get("account_id").(int)
+
+	if accountID != 0 {
+		tags = append(tags, entities.EntitySearchQueryBuilderTag{
+			Key:   "accountId",
+			Value: strconv.Itoa(accountID),
+		})
+	}
 
 	params := entities.EntitySearchQueryBuilder{
 		Name:   name,
@@ -107,6 +117,10 @@ func dataSourceNewRelicEntityRead(d *schema.ResourceData, meta interface{}) erro
 
 	var entity *entities.EntityOutlineInterface
 	for _, e := range entityResults.Results.Entities {
+		if accountID != 0 && e.GetAccountID() != accountID {
+			continue
+		}
+
 		if e.GetName() == name {
 			entity = &e
 			break
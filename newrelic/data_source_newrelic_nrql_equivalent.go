@@ -0,0 +1,178 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataSourceNewRelicNrqlEquivalent() *schema.Resource {
+	validTypes := make([]string, 0, len(nrqlEquivalentMetrics)+len(thresholdConditionTypes))
+	for k := range nrqlEquivalentMetrics {
+		validTypes = append(validTypes, k)
+	}
+	for k := range thresholdConditionTypes {
+		validTypes = append(validTypes, k)
+	}
+	sort.Strings(validTypes)
+
+	return &schema.Resource{
+		Read: dataSourceNewRelicNrqlEquivalentRead,
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(validTypes, false),
+				Description:  fmt.Sprintf("The type of the legacy condition. One of: (%s).", strings.Join(validTypes, ", ")),
+			},
+			"metric": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The metric of the newrelic_alert_condition.",
+			},
+			"entities": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "The application IDs of the newrelic_alert_condition.",
+			},
+			"condition_scope": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "application",
+				ValidateFunc: validation.StringInSlice([]string{"application", "instance"}, false),
+				Description:  "The condition_scope of the newrelic_alert_condition. Conditions scoped to instances are faceted by host.",
+			},
+			"user_defined_metric": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The user_defined_metric of the newrelic_alert_condition.",
+			},
+			"user_defined_value_function": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"average", "min", "max", "total", "sample_size"}, false),
+				Description:  "The user_defined_value_function of the newrelic_alert_condition.",
+			},
+			"event": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The event of the newrelic_infra_alert_condition, e.g. SystemSample.",
+			},
+			"select": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The select attribute of the newrelic_infra_alert_condition, e.g. cpuPercent.",
+			},
+			"where": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The where filter of the newrelic_infra_alert_condition, e.g. hostname LIKE '%cassandra%'. Also filters the hosts of servers_metric conditions.",
+			},
+			"process_where": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The process_where filter of the newrelic_infra_alert_condition.",
+			},
+			"comparison": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"operator"},
+				ValidateFunc:  validation.StringInSlice([]string{"above", "below", "equal"}, false),
+				Description:   "The comparison of the newrelic_infra_alert_condition.",
+			},
+			"operator": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"comparison"},
+				ValidateFunc:  validation.StringInSlice([]string{"above", "below", "equal"}, false),
+				Description:   "The operator of the term of the newrelic_alert_condition.",
+			},
+			"threshold": {
+				Type:        schema.TypeFloat,
+				Optional:    true,
+				Description: "The threshold of the legacy condition.",
+			},
+			"duration": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntBetween(1, 120),
+				Description:  "The duration, in minutes, of the legacy condition. Defaults to 5.",
+			},
+			"time_function": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "all",
+				ValidateFunc: validation.StringInSlice([]string{"all", "any"}, false),
+				Description:  "The time_function of the legacy condition. Defaults to all.",
+			},
+			"query": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The equivalent NRQL query.",
+			},
+			"value_function": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The value_function of the equivalent static NRQL condition.",
+			},
+			"critical": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The equivalent critical term of the NRQL condition.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"operator": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"threshold": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"threshold_duration": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"threshold_occurrences": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"expiration_duration": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The expiration_duration of the equivalent NRQL condition, for conditions on hosts that stop reporting.",
+			},
+			"open_violation_on_expiration": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "The open_violation_on_expiration of the equivalent NRQL condition.",
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicNrqlEquivalentRead(d *schema.ResourceData, meta interface{}) error {
+	conditionType := d.Get("type").(string)
+
+	log.Printf("[INFO] Deriving the NRQL equivalent of a %s condition", conditionType)
+
+	equivalent, err := expandNrqlEquivalent(d)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(fmt.Sprintf("%s:%s:%s:%g:%d", conditionType, equivalent.query, equivalent.operator, equivalent.threshold, equivalent.thresholdDuration))))
+
+	return flattenNrqlEquivalent(equivalent, d)
+}
@@ -0,0 +1,110 @@
+// +build unit
+
+package newrelic
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccNewRelicNrqlEquivalentDataSource_Offline(t *testing.T) {
+	dataSourceName := "data.newrelic_nrql_equivalent.foo"
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.providerConfig() + `
+data "newrelic_nrql_equivalent" "foo" {
+  type            = "apm_app_metric"
+  metric          = "response_time_web"
+  entities        = [123, 456]
+  condition_scope = "instance"
+  operator        = "above"
+  threshold       = 0.5
+  duration        = 10
+  time_function   = "any"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "query", "SELECT average(duration) FROM Transaction WHERE (transactionType = 'Web') AND (appId IN (123, 456)) FACET host"),
+					resource.TestCheckResourceAttr(dataSourceName, "value_function", "single_value"),
+					resource.TestCheckResourceAttr(dataSourceName, "critical.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "critical.0.operator", "above"),
+					resource.TestCheckResourceAttr(dataSourceName, "critical.0.threshold", "0.5"),
+					resource.TestCheckResourceAttr(dataSourceName, "critical.0.threshold_duration", "600"),
+					resource.TestCheckResourceAttr(dataSourceName, "critical.0.threshold_occurrences", "at_least_once"),
+					resource.TestCheckResourceAttr(dataSourceName, "expiration_duration", "0"),
+				),
+			},
+			{
+				Config: server.providerConfig() + `
+data "newrelic_nrql_equivalent" "foo" {
+  type                        = "apm_app_metric"
+  metric                      = "user_defined"
+  entities                    = [123]
+  user_defined_metric         = "Custom/Queue/Size"
+  user_defined_value_function = "total"
+  operator                    = "equal"
+  threshold                   = 0
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "query", "SELECT sum(newrelic.timeslice.value) FROM Metric WHERE (metricTimesliceName = 'Custom/Queue/Size') AND (appId IN (123))"),
+					resource.TestCheckResourceAttr(dataSourceName, "critical.0.operator", "equals"),
+					resource.TestCheckResourceAttr(dataSourceName, "critical.0.threshold_duration", "300"),
+					resource.TestCheckResourceAttr(dataSourceName, "critical.0.threshold_occurrences", "all"),
+				),
+			},
+			{
+				Config: server.providerConfig() + `
+data "newrelic_nrql_equivalent" "foo" {
+  type       = "infra_metric"
+  event      = "StorageSample"
+  select     = "diskUsedPercent"
+  where      = "hostname LIKE '%cassandra%'"
+  comparison = "above"
+  threshold  = 90
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "query", "SELECT average(diskUsedPercent) FROM StorageSample WHERE hostname LIKE '%cassandra%' FACET entityName"),
+					resource.TestCheckResourceAttr(dataSourceName, "critical.0.operator", "above"),
+					resource.TestCheckResourceAttr(dataSourceName, "critical.0.threshold", "90"),
+				),
+			},
+			{
+				Config: server.providerConfig() + `
+data "newrelic_nrql_equivalent" "foo" {
+  type     = "infra_host_not_reporting"
+  where    = "environment = 'production'"
+  duration = 15
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "query", "SELECT count(*) FROM SystemSample WHERE environment = 'production' FACET hostname"),
+					resource.TestCheckResourceAttr(dataSourceName, "critical.0.operator", "below"),
+					resource.TestCheckResourceAttr(dataSourceName, "critical.0.threshold", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "expiration_duration", "900"),
+					resource.TestCheckResourceAttr(dataSourceName, "open_violation_on_expiration", "true"),
+				),
+			},
+			{
+				Config: server.providerConfig() + `
+data "newrelic_nrql_equivalent" "foo" {
+  type      = "browser_metric"
+  metric    = "end_user_apdex"
+  operator  = "below"
+  threshold = 0.7
+}
+`,
+				ExpectError: regexp.MustCompile("the end_user_apdex metric of browser_metric conditions has no NRQL equivalent"),
+			},
+		},
+	})
+}
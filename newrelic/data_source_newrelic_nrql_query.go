@@ -0,0 +1,122 @@
+package newrelic
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/newrelic/newrelic-client-go/pkg/nrdb"
+)
+
+func dataSourceNewRelicNrqlQuery() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNewRelicNrqlQueryRead,
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The New Relic account ID to run the query against.",
+			},
+			"query": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The NRQL query to run.",
+			},
+			"results": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The rows returned by the query. Every value is converted to a string.",
+				Elem: &schema.Schema{
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicNrqlQueryRead(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	accountID := selectAccountID(providerConfig, d)
+	query := d.Get("query").(string)
+
+	log.Printf("[INFO] Running NRQL query %q in account %d", query, accountID)
+
+	var results []map[string]interface{}
+
+	switch {
+	case providerConfig.hasNerdGraphCredentials():
+		container, err := providerConfig.NewClient.Nrdb.Query(accountID, nrdb.NRQL(query))
+		if err != nil {
+			return err
+		}
+
+		for _, r := range container.Results {
+			results = append(results, r)
+		}
+	case providerConfig.hasInsightsQueryCredentials():
+		// The Insights query client is bound to the provider account.
+		if accountID != providerConfig.AccountID {
+			return fmt.Errorf("querying account %d requires a Personal API key, the Insights query key is only valid for account %d", accountID, providerConfig.AccountID)
+		}
+
+		resp, err := providerConfig.InsightsQueryClient.QueryEvents(query)
+		if err != nil {
+			return err
+		}
+
+		results = resp.Results
+	default:
+		return fmt.Errorf("running a NRQL query requires either an `api_key` or an `insights_query_key` in the provider configuration")
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(fmt.Sprintf("%d:%s", accountID, query))))
+
+	if err := d.Set("account_id", accountID); err != nil {
+		return err
+	}
+
+	return d.Set("results", flattenNrqlQueryResults(results))
+}
+
+func flattenNrqlQueryResults(results []map[string]interface{}) []map[string]string {
+	flattened := make([]map[string]string, len(results))
+
+	for i, row := range results {
+		flattened[i] = make(map[string]string, len(row))
+
+		for k, v := range row {
+			flattened[i][k] = flattenNrqlQueryValue(v)
+		}
+	}
+
+	return flattened
+}
+
+func flattenNrqlQueryValue(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case bool:
+		return strconv.FormatBool(t)
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(t)
+	default:
+		// Nested values such as percentiles or uniques are kept as JSON.
+		b, err := json.Marshal(t)
+		if err != nil {
+			return fmt.Sprintf("%v", t)
+		}
+
+		return string(b)
+	}
+}
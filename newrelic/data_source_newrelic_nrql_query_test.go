@@ -0,0 +1,71 @@
+// +build integration
+
+package newrelic
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccNewRelicNrqlQueryDataSource_Basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNewRelicNrqlQueryDataSourceConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNrqlQueryDataSourceExists("data.newrelic_nrql_query.foo"),
+					resource.TestCheckResourceAttr("data.newrelic_nrql_query.foo", "results.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicNrqlQueryDataSource_InvalidQuery(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "newrelic_nrql_query" "foo" {
+	query = "SELEKT nothing"
+}
+`,
+				ExpectError: regexp.MustCompile(`(?i)nrql`),
+			},
+		},
+	})
+}
+
+func testAccCheckNewRelicNrqlQueryDataSourceExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		r := s.RootModule().Resources[n]
+		a := r.Primary.Attributes
+
+		if r.Primary.ID == "" {
+			return fmt.Errorf("expected to get an ID for the NRQL query")
+		}
+
+		if a["results.0.count"] == "" {
+			return fmt.Errorf("expected a count in the first result row, got %v", a)
+		}
+
+		return nil
+	}
+}
+
+func testAccNewRelicNrqlQueryDataSourceConfig() string {
+	return fmt.Sprintf(`
+data "newrelic_nrql_query" "foo" {
+	account_id = %d
+	query      = "SELECT count(*) FROM Transaction SINCE 1 day ago"
+}
+`, testAccountID)
+}
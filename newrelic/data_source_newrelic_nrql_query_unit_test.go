@@ -0,0 +1,105 @@
+// +build unit
+
+package newrelic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	insights "github.com/newrelic/go-insights/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlattenNrqlQueryValue(t *testing.T) {
+	cases := map[string]struct {
+		value    interface{}
+		expected string
+	}{
+		"nil":     {nil, ""},
+		"string":  {"WebTransaction/Go/index", "WebTransaction/Go/index"},
+		"bool":    {true, "true"},
+		"integer": {float64(42), "42"},
+		"float":   {0.125, "0.125"},
+		"int":     {7, "7"},
+		"uniques": {[]interface{}{"web", "api"}, `["web","api"]`},
+		"percentile": {
+			map[string]interface{}{"95": 0.5},
+			`{"95":0.5}`,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, c.expected, flattenNrqlQueryValue(c.value))
+		})
+	}
+}
+
+// testNrqlQueryInsightsConfig returns a provider configuration with only an
+// Insights query key, querying the given server.
+func testNrqlQueryInsightsConfig(t *testing.T, server *httptest.Server) *ProviderConfig {
+	queryURL, err := url.Parse(server.URL + "/v1/accounts/1234/query")
+	require.NoError(t, err)
+
+	client := insights.NewQueryClient("NRIQ-TEST", "1234")
+	client.URL = queryURL
+
+	return &ProviderConfig{
+		AccountID:           1234,
+		InsightsQueryClient: &insightsQueryClient{QueryClient: client, httpClient: server.Client()},
+		InsightsQueryKey:    "NRIQ-TEST",
+	}
+}
+
+func TestDataSourceNewRelicNrqlQueryRead_Insights(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/accounts/1234/query", r.URL.Path)
+		assert.Equal(t, "NRIQ-TEST", r.Header.Get("X-Query-Key"))
+		assert.Equal(t, "SELECT count(*) AS total FROM Transaction", r.URL.Query().Get("nrql"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results": [{"total": 42}], "metadata": {}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	d := schema.TestResourceDataRaw(t, dataSourceNewRelicNrqlQuery().Schema, map[string]interface{}{
+		"query": "SELECT count(*) AS total FROM Transaction",
+	})
+
+	require.NoError(t, dataSourceNewRelicNrqlQueryRead(d, testNrqlQueryInsightsConfig(t, server)))
+
+	assert.NotEmpty(t, d.Id())
+	assert.Equal(t, 1234, d.Get("account_id"))
+	assert.Equal(t, 1, d.Get("results.#"))
+	assert.Equal(t, "42", d.Get("results.0.total"))
+}
+
+func TestDataSourceNewRelicNrqlQueryRead_InsightsOtherAccount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected query of the provider account: %s", r.URL)
+	}))
+	t.Cleanup(server.Close)
+
+	d := schema.TestResourceDataRaw(t, dataSourceNewRelicNrqlQuery().Schema, map[string]interface{}{
+		"account_id": 5678,
+		"query":      "SELECT count(*) FROM Transaction",
+	})
+
+	err := dataSourceNewRelicNrqlQueryRead(d, testNrqlQueryInsightsConfig(t, server))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the Insights query key is only valid for account 1234")
+}
+
+func TestDataSourceNewRelicNrqlQueryRead_NoCredentials(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceNewRelicNrqlQuery().Schema, map[string]interface{}{
+		"query": "SELECT count(*) FROM Transaction",
+	})
+
+	err := dataSourceNewRelicNrqlQueryRead(d, &ProviderConfig{AccountID: 1234})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires either an `api_key` or an `insights_query_key`")
+}
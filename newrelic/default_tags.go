@@ -0,0 +1,229 @@
+package newrelic
+
+import (
+	"encoding/base64"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/newrelic/newrelic-client-go/pkg/entities"
+	nrErrors "github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+// defaultTagsTimeout bounds how long tagging waits for a newly created entity
+// to be indexed, and for its tags to become visible.
+const defaultTagsTimeout = 2 * time.Minute
+
+// defaultTagsSchema returns the attribute tracking the provider's default tags
+// on the entity backing a resource.
+func defaultTagsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "The provider's default tags as applied to the entity.",
+	}
+}
+
+// expandDefaultTags reads the provider's default_tags block.
+func expandDefaultTags(cfg []interface{}) map[string]string {
+	tags := map[string]string{}
+
+	if len(cfg) == 0 || cfg[0] == nil {
+		return tags
+	}
+
+	for k, v := range cfg[0].(map[string]interface{})["tags"].(map[string]interface{}) {
+		tags[k] = v.(string)
+	}
+
+	return tags
+}
+
+// customizeDiffDefaultTags plans the provider's default tags for an entity, so
+// that changes to them, or tags drifting on the entity, show as a diff.
+func customizeDiffDefaultTags(d *schema.ResourceDiff, meta interface{}) error {
+	providerConfig, ok := meta.(*ProviderConfig)
+	if !ok || providerConfig == nil {
+		return nil
+	}
+
+	current := d.Get("default_tags").(map[string]interface{})
+	if d.Id() != "" && defaultTagsEqual(current, providerConfig.DefaultTags) {
+		return nil
+	}
+
+	planned := make(map[string]interface{}, len(providerConfig.DefaultTags))
+	for k, v := range providerConfig.DefaultTags {
+		planned[k] = v
+	}
+
+	return d.SetNew("default_tags", planned)
+}
+
+// readDefaultTags records which of the default tags are set on an entity. Tags
+// with keys outside the provider's default tags are left to other resources,
+// such as newrelic_entity_tags.
+func readDefaultTags(d *schema.ResourceData, meta interface{}, guid entities.EntityGUID) error {
+	providerConfig := meta.(*ProviderConfig)
+	keys := defaultTagKeys(providerConfig.DefaultTags, d.Get("default_tags").(map[string]interface{}))
+
+	out := map[string]interface{}{}
+
+	if len(keys) > 0 {
+		tags, err := providerConfig.NewClient.Entities.ListTags(guid)
+		if err != nil {
+			return fmt.Errorf("error retrieving entity tags for guid %s: %s", guid, err)
+		}
+
+		for _, t := range tags {
+			if stringInSlice(keys, t.Key) {
+				out[t.Key] = flattenDefaultTagValues(t.Values)
+			}
+		}
+	}
+
+	return d.Set("default_tags", out)
+}
+
+// updateDefaultTags applies changes to the provider's default tags to an
+// entity, removing tags whose keys are no longer defaults.
+func updateDefaultTags(d *schema.ResourceData, meta interface{}, guid entities.EntityGUID) error {
+	if !d.HasChange("default_tags") {
+		return nil
+	}
+
+	client := meta.(*ProviderConfig).NewClient
+
+	o, n := d.GetChange("default_tags")
+	oldTags := o.(map[string]interface{})
+	newTags := n.(map[string]interface{})
+
+	var staleKeys []string
+	var tags []entities.Tag
+
+	for k, v := range oldTags {
+		if nv, ok := newTags[k]; !ok || nv != v {
+			staleKeys = append(staleKeys, k)
+		}
+	}
+
+	for k, v := range newTags {
+		if ov, ok := oldTags[k]; !ok || ov != v {
+			tags = append(tags, entities.Tag{Key: k, Values: []string{v.(string)}})
+		}
+	}
+
+	sort.Strings(staleKeys)
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Key < tags[j].Key })
+
+	log.Printf("[INFO] Updating default tags for entity guid %s", guid)
+
+	// A newly created entity can take a moment to be indexed before it can be tagged.
+	return resource.Retry(defaultTagsTimeout, func() *resource.RetryError {
+		if len(staleKeys) > 0 {
+			if err := client.Entities.DeleteTags(guid, staleKeys); err != nil {
+				return resource.RetryableError(err)
+			}
+		}
+
+		if len(tags) > 0 {
+			if err := client.Entities.AddTags(guid, tags); err != nil {
+				return resource.RetryableError(err)
+			}
+		}
+
+		currentTags, err := client.Entities.ListTags(guid)
+		if err != nil {
+			if _, ok := err.(*nrErrors.NotFound); ok {
+				return resource.RetryableError(err)
+			}
+
+			return resource.NonRetryableError(fmt.Errorf("error retrieving entity tags for guid %s: %s", guid, err))
+		}
+
+		if err := checkEntityTags(currentTags, tags); err != nil {
+			return resource.RetryableError(err)
+		}
+
+		return nil
+	})
+}
+
+// deleteDefaultTags removes the provider's default tags from an entity that
+// outlives its resource, such as an APM application.
+func deleteDefaultTags(d *schema.ResourceData, meta interface{}, guid entities.EntityGUID) error {
+	keys := []string{}
+	for k := range d.Get("default_tags").(map[string]interface{}) {
+		keys = append(keys, k)
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	sort.Strings(keys)
+
+	log.Printf("[INFO] Removing default tags from entity guid %s", guid)
+
+	if err := meta.(*ProviderConfig).NewClient.Entities.DeleteTags(guid, keys); err != nil {
+		if _, ok := err.(*nrErrors.NotFound); !ok {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func defaultTagsEqual(current map[string]interface{}, desired map[string]string) bool {
+	if len(current) != len(desired) {
+		return false
+	}
+
+	for k, v := range desired {
+		if current[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+func defaultTagKeys(defaults map[string]string, current map[string]interface{}) []string {
+	keys := []string{}
+
+	for k := range defaults {
+		keys = append(keys, k)
+	}
+
+	for k := range current {
+		if _, ok := defaults[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// flattenDefaultTagValues joins the values of a tag, so that values added to a
+// default tag outside of Terraform are detected as drift.
+func flattenDefaultTagValues(values []string) string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+
+	return strings.Join(sorted, ",")
+}
+
+// entityGUID builds the GUID of an entity that the REST APIs only identify by
+// its domain-specific ID.
+func entityGUID(accountID int, domain string, entityType string, id string) entities.EntityGUID {
+	raw := fmt.Sprintf("%d|%s|%s|%s", accountID, domain, entityType, id)
+
+	return entities.EntityGUID(base64.RawStdEncoding.EncodeToString([]byte(raw)))
+}
@@ -0,0 +1,59 @@
+// +build unit
+
+package newrelic
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandDefaultTags(t *testing.T) {
+	assert.Equal(t, map[string]string{}, expandDefaultTags(nil))
+	assert.Equal(t, map[string]string{}, expandDefaultTags([]interface{}{nil}))
+
+	cfg := []interface{}{
+		map[string]interface{}{
+			"tags": map[string]interface{}{
+				"team":       "platform",
+				"managed_by": "terraform",
+			},
+		},
+	}
+
+	assert.Equal(t, map[string]string{"team": "platform", "managed_by": "terraform"}, expandDefaultTags(cfg))
+}
+
+func TestDefaultTagsEqual(t *testing.T) {
+	desired := map[string]string{"team": "platform", "managed_by": "terraform"}
+
+	assert.True(t, defaultTagsEqual(map[string]interface{}{"team": "platform", "managed_by": "terraform"}, desired))
+	assert.False(t, defaultTagsEqual(map[string]interface{}{"team": "platform"}, desired))
+	assert.False(t, defaultTagsEqual(map[string]interface{}{"team": "platform,sre", "managed_by": "terraform"}, desired))
+	assert.True(t, defaultTagsEqual(map[string]interface{}{}, map[string]string{}))
+}
+
+func TestDefaultTagKeys(t *testing.T) {
+	keys := defaultTagKeys(
+		map[string]string{"team": "platform", "managed_by": "terraform"},
+		map[string]interface{}{"team": "platform", "cost_center": "42"},
+	)
+
+	assert.Equal(t, []string{"cost_center", "managed_by", "team"}, keys)
+}
+
+func TestFlattenDefaultTagValues(t *testing.T) {
+	assert.Equal(t, "platform", flattenDefaultTagValues([]string{"platform"}))
+	assert.Equal(t, "platform,sre", flattenDefaultTagValues([]string{"sre", "platform"}))
+}
+
+func TestEntityGUID(t *testing.T) {
+	guid := entityGUID(1234, "SYNTH", "MONITOR", "5cd1c4a2-2c47-4a3f-8a1b-0bbd0e1e3a5b")
+
+	decoded, err := base64.RawStdEncoding.DecodeString(string(guid))
+	assert.NoError(t, err)
+	assert.Equal(t, "1234|SYNTH|MONITOR|5cd1c4a2-2c47-4a3f-8a1b-0bbd0e1e3a5b", string(decoded))
+
+	assert.Equal(t, "MTIzNHxWSVp8REFTSEJPQVJEfDE", string(entityGUID(1234, "VIZ", "DASHBOARD", "1")))
+}
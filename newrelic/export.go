@@ -0,0 +1,440 @@
+package newrelic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	nr "github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+)
+
+// ExportOptions configures an export of existing account configuration to HCL.
+type ExportOptions struct {
+	// AccountID is the New Relic account to walk.
+	AccountID int
+	// HCL receives the generated resource blocks.
+	HCL io.Writer
+	// ImportScript receives the `terraform import` commands matching the HCL.
+	ImportScript io.Writer
+}
+
+// exportedResource is a single resource block discovered during an export.
+type exportedResource struct {
+	resourceType string
+	name         string
+	importID     string
+	data         *schema.ResourceData
+	// references maps attribute names to HCL expressions that replace the literal value.
+	references map[string]string
+}
+
+type exporter struct {
+	client    *nr.NewRelic
+	accountID int
+	resources []*exportedResource
+	names     map[string]map[string]bool
+	policies  map[int]string
+}
+
+// Export walks a New Relic account and writes its alert policies, NRQL alert
+// conditions and New Relic One dashboards as HCL, along with a shell script that
+// imports them into Terraform state. Attribute values are produced by the same
+// flatten functions the resources use on read, so a `terraform plan` after the
+// import should be clean.
+func Export(cfg Config, opts ExportOptions) error {
+	if opts.AccountID == 0 {
+		return fmt.Errorf("an account ID is required to export configuration")
+	}
+
+	if cfg.userAgent == "" {
+		cfg.userAgent = fmt.Sprintf("%s/%s (export)", TerraformProviderProductUserAgent, ProviderVersion)
+	}
+
+	client, err := cfg.Client()
+	if err != nil {
+		return fmt.Errorf("error initializing newrelic-client-go: %w", err)
+	}
+
+	e := &exporter{
+		client:    client,
+		accountID: opts.AccountID,
+		names:     map[string]map[string]bool{},
+		policies:  map[int]string{},
+	}
+
+	if err := e.exportAlertPolicies(); err != nil {
+		return err
+	}
+
+	if err := e.exportNrqlAlertConditions(); err != nil {
+		return err
+	}
+
+	if err := e.exportOneDashboards(); err != nil {
+		return err
+	}
+
+	return e.write(opts.HCL, opts.ImportScript)
+}
+
+func (e *exporter) exportAlertPolicies() error {
+	log.Printf("[INFO] Exporting New Relic alert policies from account %d", e.accountID)
+
+	policies, err := e.client.Alerts.QueryPolicySearch(e.accountID, alerts.AlertsPoliciesSearchCriteriaInput{})
+	if err != nil {
+		return err
+	}
+
+	channels, err := e.client.Alerts.ListChannels()
+	if err != nil {
+		return err
+	}
+
+	channelIDsByPolicy := map[int][]int{}
+	for _, c := range channels {
+		for _, policyID := range c.Links.PolicyIDs {
+			channelIDsByPolicy[policyID] = append(channelIDsByPolicy[policyID], c.ID)
+		}
+	}
+
+	for _, policy := range policies {
+		policyID, err := strconv.Atoi(policy.ID)
+		if err != nil {
+			return err
+		}
+
+		d := resourceNewRelicAlertPolicy().Data(nil)
+		d.SetId(policy.ID)
+
+		if err := flattenAlertPolicy(policy, d, e.accountID); err != nil {
+			return err
+		}
+
+		if channelIDs, ok := channelIDsByPolicy[policyID]; ok {
+			sortIntegerSlice(channelIDs)
+			if err := d.Set("channel_ids", channelIDs); err != nil {
+				return err
+			}
+		}
+
+		r := e.add("newrelic_alert_policy", policy.Name, fmt.Sprintf("%d:%d", policyID, e.accountID), d)
+		e.policies[policyID] = r.name
+	}
+
+	return nil
+}
+
+func (e *exporter) exportNrqlAlertConditions() error {
+	log.Printf("[INFO] Exporting New Relic NRQL alert conditions from account %d", e.accountID)
+
+	conditions, err := e.client.Alerts.SearchNrqlConditionsQuery(e.accountID, alerts.NrqlConditionsSearchCriteria{})
+	if err != nil {
+		return err
+	}
+
+	for _, condition := range conditions {
+		d := resourceNewRelicNrqlAlertCondition().Data(nil)
+		d.SetId(fmt.Sprintf("%s:%s", condition.PolicyID, condition.ID))
+
+		// Seed the non-deprecated attribute so the flatten prefers it over `violation_time_limit`.
+		if err := d.Set("violation_time_limit_seconds", condition.ViolationTimeLimitSeconds); err != nil {
+			return err
+		}
+
+		c := newNrqlAlertCondition(condition)
+		if c.Signal, err = getNrqlConditionSignal(e.client, e.accountID, condition.ID); err != nil {
+			return err
+		}
+
+		if err := flattenNrqlAlertCondition(e.accountID, c, d); err != nil {
+			return err
+		}
+
+		// Conditions still using the legacy evaluation offset report it as an
+		// aggregation method and delay as well, which can't be configured together.
+		if _, ok := d.GetOk("nrql.0.evaluation_offset"); ok {
+			for _, k := range []string{"aggregation_method", "aggregation_delay"} {
+				if err := d.Set(k, ""); err != nil {
+					return err
+				}
+			}
+		}
+
+		importID := fmt.Sprintf("%s:%s:%s", condition.PolicyID, condition.ID, strings.ToLower(string(condition.Type)))
+		r := e.add("newrelic_nrql_alert_condition", condition.Name, importID, d)
+
+		if policyID, err := strconv.Atoi(condition.PolicyID); err == nil {
+			if policyName, ok := e.policies[policyID]; ok {
+				r.references["policy_id"] = fmt.Sprintf("newrelic_alert_policy.%s.id", policyName)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (e *exporter) exportOneDashboards() error {
+	log.Printf("[INFO] Exporting New Relic One dashboards from account %d", e.accountID)
+
+	guids, err := searchDashboards(e.client, e.accountID)
+	if err != nil {
+		return err
+	}
+
+	for _, guid := range guids {
+		dashboard, variables, err := getDashboard(e.client, guid)
+		if err != nil {
+			return err
+		}
+
+		d := resourceNewRelicOneDashboard().Data(nil)
+		d.SetId(string(dashboard.GUID))
+
+		if err := flattenDashboardEntity(dashboard, variables, d); err != nil {
+			return err
+		}
+
+		e.add("newrelic_one_dashboard", dashboard.Name, string(dashboard.GUID), d)
+	}
+
+	return nil
+}
+
+// add registers a resource under a unique, HCL safe name derived from displayName.
+func (e *exporter) add(resourceType string, displayName string, importID string, d *schema.ResourceData) *exportedResource {
+	if _, ok := e.names[resourceType]; !ok {
+		e.names[resourceType] = map[string]bool{}
+	}
+
+	base := exportResourceName(displayName)
+	name := base
+	for i := 2; e.names[resourceType][name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	e.names[resourceType][name] = true
+
+	r := &exportedResource{
+		resourceType: resourceType,
+		name:         name,
+		importID:     importID,
+		data:         d,
+		references:   map[string]string{},
+	}
+
+	e.resources = append(e.resources, r)
+
+	return r
+}
+
+func (e *exporter) write(hcl io.Writer, importScript io.Writer) error {
+	var body bytes.Buffer
+	var script bytes.Buffer
+
+	fmt.Fprintf(&body, "# Generated by %s export from account %d.\n", TerraformProviderProductUserAgent, e.accountID)
+	fmt.Fprintf(&script, "#!/bin/sh\n# Generated by %s export from account %d.\nset -e\n\n", TerraformProviderProductUserAgent, e.accountID)
+
+	provider := Provider().(*schema.Provider)
+
+	for _, r := range e.resources {
+		s := provider.ResourcesMap[r.resourceType].Schema
+
+		values := make(map[string]interface{}, len(s))
+		for k := range s {
+			values[k] = r.data.Get(k)
+		}
+
+		fmt.Fprintf(&body, "\nresource %q %q {\n", r.resourceType, r.name)
+		writeHCLBody(&body, s, values, r.references, 1)
+		fmt.Fprintf(&body, "}\n")
+
+		fmt.Fprintf(&script, "terraform import %s.%s '%s'\n", r.resourceType, r.name, r.importID)
+	}
+
+	if _, err := hcl.Write(body.Bytes()); err != nil {
+		return err
+	}
+
+	if _, err := importScript.Write(script.Bytes()); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var exportResourceNameRegexp = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// exportResourceName converts a display name into a valid Terraform resource name.
+func exportResourceName(displayName string) string {
+	name := exportResourceNameRegexp.ReplaceAllString(strings.ToLower(displayName), "_")
+	name = strings.Trim(name, "_")
+
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "r_" + name
+	}
+
+	return strings.TrimRight(name, "_")
+}
+
+// writeHCLBody renders the configurable attributes of values, attributes first and
+// nested blocks second, skipping anything left at its zero or default value.
+func writeHCLBody(w *bytes.Buffer, s map[string]*schema.Schema, values map[string]interface{}, references map[string]string, depth int) {
+	indent := strings.Repeat("  ", depth)
+
+	var attributes []string
+	var blocks []string
+
+	for k, sch := range s {
+		if !isExportedAttribute(sch) {
+			continue
+		}
+
+		if _, ok := sch.Elem.(*schema.Resource); ok {
+			blocks = append(blocks, k)
+		} else {
+			attributes = append(attributes, k)
+		}
+	}
+
+	sort.Strings(attributes)
+	sort.Strings(blocks)
+
+	for _, k := range attributes {
+		if ref, ok := references[k]; ok {
+			fmt.Fprintf(w, "%s%s = %s\n", indent, k, ref)
+			continue
+		}
+
+		v := values[k]
+		if !shouldExportValue(s[k], v) {
+			continue
+		}
+
+		fmt.Fprintf(w, "%s%s = %s\n", indent, k, hclValue(v, depth))
+	}
+
+	for _, k := range blocks {
+		elem := s[k].Elem.(*schema.Resource)
+
+		for _, item := range hclList(values[k]) {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			fmt.Fprintf(w, "\n%s%s {\n", indent, k)
+			writeHCLBody(w, elem.Schema, m, nil, depth+1)
+			fmt.Fprintf(w, "%s}\n", indent)
+		}
+	}
+}
+
+// isExportedAttribute reports whether an attribute belongs in configuration.
+// Purely computed and deprecated attributes are left out.
+func isExportedAttribute(sch *schema.Schema) bool {
+	if sch.Computed && !sch.Optional && !sch.Required {
+		return false
+	}
+
+	return sch.Deprecated == ""
+}
+
+func shouldExportValue(sch *schema.Schema, v interface{}) bool {
+	if v == nil {
+		return false
+	}
+
+	if sch.Required {
+		return true
+	}
+
+	if sch.Default != nil {
+		return !reflect.DeepEqual(sch.Default, v)
+	}
+
+	switch t := v.(type) {
+	case string:
+		return t != ""
+	case int:
+		return t != 0
+	case float64:
+		return t != 0
+	case bool:
+		return t
+	case map[string]interface{}:
+		return len(t) > 0
+	default:
+		return len(hclList(v)) > 0
+	}
+}
+
+func hclList(v interface{}) []interface{} {
+	switch t := v.(type) {
+	case []interface{}:
+		return t
+	case *schema.Set:
+		return t.List()
+	}
+
+	return nil
+}
+
+func hclValue(v interface{}, depth int) string {
+	switch t := v.(type) {
+	case string:
+		return hclString(t)
+	case int:
+		return strconv.Itoa(t)
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(t)
+	case map[string]interface{}:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		indent := strings.Repeat("  ", depth+1)
+		var b strings.Builder
+		b.WriteString("{\n")
+		for _, k := range keys {
+			fmt.Fprintf(&b, "%s%s = %s\n", indent, hclString(k), hclValue(t[k], depth+1))
+		}
+		b.WriteString(strings.Repeat("  ", depth) + "}")
+
+		return b.String()
+	}
+
+	items := hclList(v)
+	values := make([]string, len(items))
+	for i, item := range items {
+		values[i] = hclValue(item, depth)
+	}
+
+	return "[" + strings.Join(values, ", ") + "]"
+}
+
+// hclString quotes a string for HCL, escaping template sequences.
+func hclString(s string) string {
+	var b bytes.Buffer
+
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+
+	quoted := strings.TrimSuffix(b.String(), "\n")
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+	quoted = strings.ReplaceAll(quoted, "%{", "%%{")
+
+	return quoted
+}
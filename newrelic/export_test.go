@@ -0,0 +1,150 @@
+// +build unit
+
+package newrelic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testExportAccountID = 1234
+
+// newExportTestServer returns a stand-in for the REST and NerdGraph APIs
+// serving a single policy and NRQL condition, and two dashboards over two
+// pages of search results.
+func newExportTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if strings.HasSuffix(r.URL.Path, "/alerts_channels.json") {
+			fmt.Fprint(w, `{"channels":[{"id":55,"name":"ops","type":"email","configuration":{"recipients":"ops@example.com"},"links":{"policy_ids":[111]}}]}`)
+			return
+		}
+
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		switch {
+		case strings.Contains(req.Query, "policiesSearch"):
+			fmt.Fprint(w, `{"data":{"actor":{"account":{"alerts":{"policiesSearch":{"nextCursor":null,"policies":[
+				{"accountId":1234,"id":"111","incidentPreference":"PER_CONDITION","name":"Golden Signals"}
+			]}}}}}}`)
+		case strings.Contains(req.Query, "nrqlConditionsSearch"):
+			fmt.Fprint(w, `{"data":{"actor":{"account":{"alerts":{"nrqlConditionsSearch":{"nextCursor":null,"nrqlConditions":[
+				{"id":"222","policyId":"111","name":"High ${latency}","enabled":false,"type":"STATIC","valueFunction":"SINGLE_VALUE",
+				 "violationTimeLimitSeconds":3600,"nrql":{"query":"SELECT average(duration) FROM Transaction","evaluationOffset":3},
+				 "terms":[{"operator":"ABOVE","priority":"CRITICAL","threshold":1.5,"thresholdDuration":300,"thresholdOccurrences":"ALL"}],
+				 "expiration":{"closeViolationsOnExpiration":false,"openViolationOnExpiration":false,"expirationDuration":null},
+				 "signal":{"aggregationWindow":60,"fillOption":"NONE","fillValue":null}}
+			]}}}}}}`)
+		case strings.Contains(req.Query, "nrqlCondition(id"):
+			fmt.Fprint(w, `{"data":{"actor":{"account":{"alerts":{"nrqlCondition":{
+				"signal":{"aggregationWindow":60,"fillOption":"NONE","fillValue":null,"aggregationMethod":"CADENCE","aggregationDelay":180}
+			}}}}}}`)
+		case strings.Contains(req.Query, "entitySearch"):
+			assert.Equal(t, "accountId = 1234 AND type = 'DASHBOARD'", req.Variables["query"])
+
+			if req.Variables["cursor"] == nil {
+				fmt.Fprint(w, `{"data":{"actor":{"entitySearch":{"results":{"nextCursor":"page-2","entities":[
+					{"__typename":"DashboardEntityOutline","guid":"MTIzNHxWSVp8REFTSEJPQVJEfDE"},
+					{"__typename":"DashboardEntityOutline","guid":"MTIzNHxWSVp8REFTSEJPQVJEfDI","dashboardParentGuid":"MTIzNHxWSVp8REFTSEJPQVJEfDE"}
+				]}}}}}`)
+				return
+			}
+
+			assert.Equal(t, "page-2", req.Variables["cursor"])
+			fmt.Fprint(w, `{"data":{"actor":{"entitySearch":{"results":{"nextCursor":null,"entities":[
+				{"__typename":"DashboardEntityOutline","guid":"MTIzNHxWSVp8REFTSEJPQVJEfDM"}
+			]}}}}}`)
+		case strings.Contains(req.Query, "entity(guid"):
+			name := "Service Overview"
+			if req.Variables["guid"] == "MTIzNHxWSVp8REFTSEJPQVJEfDM" {
+				name = "Checkout"
+			}
+
+			fmt.Fprintf(w, `{"data":{"actor":{"entity":{"__typename":"DashboardEntity","accountId":1234,"guid":%q,
+				"name":%q,"permissions":"PUBLIC_READ_ONLY","pages":[{"guid":"MTIzNHxWSVp8REFTSEJPQVJEfDI","name":"Page","widgets":[
+					{"id":"1","title":"Throughput","visualization":{"id":"viz.line"},"layout":{"column":1,"row":1,"height":3,"width":6},
+					 "configuration":{"line":{"nrqlQueries":[{"accountId":1234,"query":"SELECT rate(count(*), 1 minute) FROM Transaction TIMESERIES"}]}}}
+				]}]}}}}`, req.Variables["guid"], name)
+		default:
+			t.Errorf("unexpected NerdGraph query: %s", req.Query)
+		}
+	}))
+}
+
+func TestExport(t *testing.T) {
+	server := newExportTestServer(t)
+	defer server.Close()
+
+	cfg := Config{
+		PersonalAPIKey:  "NRAK-TEST",
+		Region:          "US",
+		APIURL:          server.URL,
+		NerdGraphAPIURL: server.URL + "/graphql",
+	}
+
+	var hcl, script bytes.Buffer
+
+	err := Export(cfg, ExportOptions{
+		AccountID:    testExportAccountID,
+		HCL:          &hcl,
+		ImportScript: &script,
+	})
+	require.NoError(t, err)
+
+	out := hcl.String()
+
+	assert.Contains(t, out, `resource "newrelic_alert_policy" "golden_signals" {`)
+	assert.Contains(t, out, `incident_preference = "PER_CONDITION"`)
+	assert.Contains(t, out, `channel_ids = [55]`)
+
+	assert.Contains(t, out, `resource "newrelic_nrql_alert_condition" "high_latency" {`)
+	assert.Contains(t, out, `name = "High $${latency}"`)
+	assert.Contains(t, out, `policy_id = newrelic_alert_policy.golden_signals.id`)
+	assert.Contains(t, out, `enabled = false`)
+	assert.Contains(t, out, `violation_time_limit_seconds = 3600`)
+	assert.Contains(t, out, `threshold_duration = 300`)
+	assert.Contains(t, out, `evaluation_offset = 3`)
+	assert.NotContains(t, out, "aggregation_method", "aggregation options conflict with the evaluation offset")
+	assert.NotContains(t, out, "violation_time_limit =", "deprecated attributes must not be exported")
+	assert.NotContains(t, out, `type = "static"`, "default values must not be exported")
+
+	assert.Contains(t, out, `resource "newrelic_one_dashboard" "service_overview" {`)
+	assert.Contains(t, out, `widget_line {`)
+	assert.Contains(t, out, `width = 6`)
+	assert.Contains(t, out, `resource "newrelic_one_dashboard" "checkout" {`)
+	assert.NotContains(t, out, "guid =", "computed attributes must not be exported")
+
+	assert.Equal(t, 4, strings.Count(out, "\nresource "))
+
+	imports := script.String()
+	assert.Contains(t, imports, "terraform import newrelic_alert_policy.golden_signals '111:1234'\n")
+	assert.Contains(t, imports, "terraform import newrelic_nrql_alert_condition.high_latency '111:222:static'\n")
+	assert.Contains(t, imports, "terraform import newrelic_one_dashboard.service_overview 'MTIzNHxWSVp8REFTSEJPQVJEfDE'\n")
+	assert.Contains(t, imports, "terraform import newrelic_one_dashboard.checkout 'MTIzNHxWSVp8REFTSEJPQVJEfDM'\n")
+}
+
+func TestExportResourceName(t *testing.T) {
+	assert.Equal(t, "golden_signals", exportResourceName("Golden Signals"))
+	assert.Equal(t, "api_p95_latency", exportResourceName("  API: p95 latency!"))
+	assert.Equal(t, "r_5xx_errors", exportResourceName("5xx errors"))
+	assert.Equal(t, "r", exportResourceName("***"))
+}
+
+func TestHCLString(t *testing.T) {
+	assert.Equal(t, `"plain"`, hclString("plain"))
+	assert.Equal(t, `"line\nbreak \"quoted\""`, hclString("line\nbreak \"quoted\""))
+	assert.Equal(t, `"$${var} and %%{if}"`, hclString("${var} and %{if}"))
+}
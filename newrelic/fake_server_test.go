@@ -0,0 +1,1580 @@
+// +build unit
+
+package newrelic
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// testFakeAccountID is the only account known to the fake server.
+const testFakeAccountID = 1234
+
+// testFakeObject is a JSON object as stored and returned by the fake server.
+// Objects are kept as decoded JSON rather than client types, so that attributes
+// unknown to newrelic-client-go round trip as they do against the real APIs.
+type testFakeObject = map[string]interface{}
+
+// testFakeConditionKind describes one of the REST v2 condition collections,
+// which only differ in their paths and the keys wrapping their payloads.
+type testFakeConditionKind struct {
+	path     string
+	singular string
+	plural   string
+}
+
+var testFakeConditionKinds = []testFakeConditionKind{
+	{path: "alerts_conditions", singular: "condition", plural: "conditions"},
+	{path: "alerts_plugins_conditions", singular: "plugins_condition", plural: "plugins_conditions"},
+	{path: "alerts_synthetics_conditions", singular: "synthetics_condition", plural: "synthetics_conditions"},
+	{path: "alerts_location_failure_conditions", singular: "location_failure_condition", plural: "location_failure_conditions"},
+}
+
+type testFakeCondition struct {
+	kind     testFakeConditionKind
+	policyID int
+	object   testFakeObject
+}
+
+// testFakeServer is an in-process stand-in for part of the REST v2,
+// Synthetics, Infrastructure and NerdGraph APIs, keeping just enough state for
+// resources to be created, read, updated, imported and deleted without network
+// access. It covers alert policies, channels and conditions (REST, infra and
+// NRQL), synthetics monitors, entity tags, notification destinations and
+// channels, workflows and dashboards. Other resources, such as muting rules,
+// workloads, API access keys or APM applications, are only covered by
+// acceptance tests.
+type testFakeServer struct {
+	*httptest.Server
+
+	t  *testing.T
+	mu sync.Mutex
+
+	lastID          int
+	channelListings int
+	policies        map[int]testFakeObject
+	channels        map[int]testFakeObject
+	conditions      map[int]*testFakeCondition
+	infraConditions map[int]testFakeObject
+	nrqlConditions  map[int]testFakeObject
+	monitors        map[string]testFakeObject
+	tags            map[string]map[string][]string
+	destinations    map[string]testFakeObject
+	channelsV2      map[string]testFakeObject
+	workflows       map[string]testFakeObject
+	dashboards      map[string]testFakeObject
+}
+
+// newTestFakeServer starts a fake server that is closed when the test ends.
+func newTestFakeServer(t *testing.T) *testFakeServer {
+	s := &testFakeServer{
+		t:               t,
+		policies:        map[int]testFakeObject{},
+		channels:        map[int]testFakeObject{},
+		conditions:      map[int]*testFakeCondition{},
+		infraConditions: map[int]testFakeObject{},
+		nrqlConditions:  map[int]testFakeObject{},
+		monitors:        map[string]testFakeObject{},
+		tags:            map[string]map[string][]string{},
+		destinations:    map[string]testFakeObject{},
+		channelsV2:      map[string]testFakeObject{},
+		workflows:       map[string]testFakeObject{},
+		dashboards:      map[string]testFakeObject{},
+	}
+
+	s.Server = httptest.NewServer(s)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// providers returns a provider instance of its own for the test, so tests
+// against different fake servers can run in parallel.
+func (s *testFakeServer) providers() map[string]terraform.ResourceProvider {
+	return map[string]terraform.ResourceProvider{
+		"newrelic": Provider(),
+	}
+}
+
+// providerConfig returns a provider block pointing every API at the server,
+// with any additional settings given appended to the block.
+func (s *testFakeServer) providerConfig(settings ...string) string {
+	return fmt.Sprintf(`
+provider "newrelic" {
+  account_id             = %[1]d
+  api_key                = "NRAK-TEST"
+  region                 = "US"
+  api_url                = "%[2]s/v2"
+  nerdgraph_api_url      = "%[2]s/graphql"
+  synthetics_api_url     = "%[2]s/synthetics"
+  infrastructure_api_url = "%[2]s/infrastructure"
+  max_retries            = 0
+
+  %[3]s
+}
+`, testFakeAccountID, s.URL, strings.Join(settings, "\n"))
+}
+
+// checkDestroy verifies that nothing created during the test is left behind.
+func (s *testFakeServer) checkDestroy(*terraform.State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	remaining := len(s.policies) + len(s.channels) + len(s.conditions) + len(s.infraConditions) + len(s.nrqlConditions) + len(s.monitors)
+	remaining += len(s.destinations) + len(s.channelsV2) + len(s.workflows) + len(s.dashboards)
+	for _, tags := range s.tags {
+		remaining += len(tags)
+	}
+
+	if remaining > 0 {
+		return fmt.Errorf("%d objects remain on the fake server", remaining)
+	}
+
+	return nil
+}
+
+func (s *testFakeServer) nextID() int {
+	s.lastID++
+	return s.lastID
+}
+
+func (s *testFakeServer) nextUUID() string {
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", s.nextID())
+}
+
+// nextGUID returns an entity GUID of the given domain and type, e.g.
+// VIZ|DASHBOARD.
+func (s *testFakeServer) nextGUID(domainType string) string {
+	return base64.RawStdEncoding.EncodeToString([]byte(fmt.Sprintf("%d|%s|%d", testFakeAccountID, domainType, s.nextID())))
+}
+
+func (s *testFakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	defer func() {
+		if err := recover(); err != nil {
+			s.t.Errorf("fake server: handling %s %s: %v", r.Method, r.URL, err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Header.Get("Api-Key") == "" && r.Header.Get("X-Api-Key") == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	p := r.URL.Path
+	handled := false
+
+	switch {
+	case p == "/graphql":
+		handled = s.serveNerdGraph(w, r)
+	case strings.HasPrefix(p, "/v2/"):
+		handled = s.serveREST(w, r, strings.TrimPrefix(p, "/v2/"))
+	case strings.HasPrefix(p, "/infrastructure/"):
+		handled = s.serveInfrastructure(w, r, strings.TrimPrefix(p, "/infrastructure/"))
+	case strings.HasPrefix(p, "/synthetics/"):
+		handled = s.serveSynthetics(w, r, strings.TrimPrefix(p, "/synthetics/"))
+	}
+
+	if !handled {
+		s.t.Errorf("fake server: unexpected request %s %s", r.Method, r.URL)
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+//
+// REST v2
+//
+
+var (
+	testFakeChannelPath         = regexp.MustCompile(`^alerts_channels/(\d+)\.json$`)
+	testFakeConditionPolicyPath = regexp.MustCompile(`^(\w+)/policies/(\d+)\.json$`)
+	testFakeConditionPath       = regexp.MustCompile(`^(\w+)/(\d+)\.json$`)
+)
+
+func (s *testFakeServer) serveREST(w http.ResponseWriter, r *http.Request, p string) bool {
+	switch {
+	case p == "alerts_channels.json" && r.Method == http.MethodGet:
+		s.channelListings++
+
+		channels := []testFakeObject{}
+		for _, id := range testFakeSortedIDs(s.channels) {
+			channels = append(channels, s.channels[id])
+		}
+
+		s.write(w, http.StatusOK, testFakeObject{"channels": channels})
+
+	case p == "alerts_channels.json" && r.Method == http.MethodPost:
+		channel := s.decodeObject(r, "channel")
+		channel["id"] = s.nextID()
+		channel["links"] = testFakeObject{"policy_ids": []int{}}
+		s.channels[channel["id"].(int)] = channel
+
+		s.write(w, http.StatusCreated, testFakeObject{"channels": []testFakeObject{channel}})
+
+	case testFakeChannelPath.MatchString(p) && r.Method == http.MethodDelete:
+		id, _ := strconv.Atoi(testFakeChannelPath.FindStringSubmatch(p)[1])
+
+		channel, ok := s.channels[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return true
+		}
+
+		delete(s.channels, id)
+		s.write(w, http.StatusOK, testFakeObject{"channel": channel})
+
+	case p == "alerts_policy_channels.json" && r.Method == http.MethodPut:
+		policyID, _ := strconv.Atoi(r.URL.Query().Get("policy_id"))
+		if _, ok := s.policies[policyID]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return true
+		}
+
+		channelIDs := []int{}
+		for _, raw := range strings.Split(r.URL.Query().Get("channel_ids"), ",") {
+			id, _ := strconv.Atoi(raw)
+			if _, ok := s.channels[id]; !ok {
+				s.write(w, http.StatusUnprocessableEntity, testFakeObject{"error": testFakeObject{"title": fmt.Sprintf("Channel %s not found", raw)}})
+				return true
+			}
+
+			channelIDs = append(channelIDs, id)
+		}
+
+		for _, id := range channelIDs {
+			s.linkChannel(id, policyID, true)
+		}
+
+		s.write(w, http.StatusOK, testFakeObject{"policy": testFakeObject{"id": policyID, "channel_ids": s.policyChannelIDs(policyID)}})
+
+	case p == "alerts_policy_channels.json" && r.Method == http.MethodDelete:
+		policyID, _ := strconv.Atoi(r.URL.Query().Get("policy_id"))
+		channelID, _ := strconv.Atoi(r.URL.Query().Get("channel_id"))
+
+		channel, ok := s.channels[channelID]
+		if !ok || !testFakeHasInt(s.policyChannelIDs(policyID), channelID) {
+			w.WriteHeader(http.StatusNotFound)
+			return true
+		}
+
+		s.linkChannel(channelID, policyID, false)
+		s.write(w, http.StatusOK, testFakeObject{"channel": channel})
+
+	default:
+		return s.serveRESTConditions(w, r, p)
+	}
+
+	return true
+}
+
+func (s *testFakeServer) serveRESTConditions(w http.ResponseWriter, r *http.Request, p string) bool {
+	for _, kind := range testFakeConditionKinds {
+		switch {
+		case p == kind.path+".json" && r.Method == http.MethodGet:
+			policyID, _ := strconv.Atoi(r.URL.Query().Get("policy_id"))
+			s.write(w, http.StatusOK, testFakeObject{kind.plural: s.listConditions(kind, policyID)})
+
+			return true
+
+		case testFakeConditionPolicyPath.MatchString(p) && strings.HasPrefix(p, kind.path+"/"):
+			policyID, _ := strconv.Atoi(testFakeConditionPolicyPath.FindStringSubmatch(p)[2])
+			if _, ok := s.policies[policyID]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return true
+			}
+
+			switch r.Method {
+			case http.MethodGet:
+				s.write(w, http.StatusOK, testFakeObject{kind.plural: s.listConditions(kind, policyID)})
+			case http.MethodPost:
+				condition := s.decodeObject(r, kind.singular)
+				condition["id"] = s.nextID()
+				s.conditions[condition["id"].(int)] = &testFakeCondition{kind: kind, policyID: policyID, object: condition}
+
+				s.write(w, http.StatusCreated, testFakeObject{kind.singular: condition})
+			default:
+				return false
+			}
+
+			return true
+
+		case testFakeConditionPath.MatchString(p) && strings.HasPrefix(p, kind.path+"/"):
+			id, _ := strconv.Atoi(testFakeConditionPath.FindStringSubmatch(p)[2])
+
+			existing, ok := s.conditions[id]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return true
+			}
+
+			switch r.Method {
+			case http.MethodPut:
+				condition := s.decodeObject(r, kind.singular)
+				condition["id"] = id
+				existing.object = condition
+
+				s.write(w, http.StatusOK, testFakeObject{kind.singular: condition})
+			case http.MethodDelete:
+				// Conditions of any kind can be deleted through alerts_conditions.
+				delete(s.conditions, id)
+				s.write(w, http.StatusOK, testFakeObject{existing.kind.singular: existing.object})
+			default:
+				return false
+			}
+
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *testFakeServer) listConditions(kind testFakeConditionKind, policyID int) []testFakeObject {
+	conditions := []testFakeObject{}
+
+	for _, id := range testFakeSortedIDs(s.conditions) {
+		c := s.conditions[id]
+		if c.kind == kind && c.policyID == policyID {
+			conditions = append(conditions, c.object)
+		}
+	}
+
+	return conditions
+}
+
+func (s *testFakeServer) policyChannelIDs(policyID int) []int {
+	ids := []int{}
+
+	for _, id := range testFakeSortedIDs(s.channels) {
+		links := s.channels[id]["links"].(testFakeObject)
+		if testFakeHasInt(links["policy_ids"].([]int), policyID) {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// addChannel adds an email alert channel that is not managed by the test
+// configuration, returning its ID.
+func (s *testFakeServer) addChannel(name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.nextID()
+	s.channels[id] = testFakeObject{
+		"id":            id,
+		"name":          name,
+		"type":          "email",
+		"configuration": testFakeObject{"recipients": "terraform-acctest+foo@hashicorp.com"},
+		"links":         testFakeObject{"policy_ids": []int{}},
+	}
+
+	return id
+}
+
+func (s *testFakeServer) linkChannel(channelID int, policyID int, linked bool) {
+	links := s.channels[channelID]["links"].(testFakeObject)
+	policyIDs := []int{}
+
+	for _, id := range links["policy_ids"].([]int) {
+		if id != policyID {
+			policyIDs = append(policyIDs, id)
+		}
+	}
+
+	if linked {
+		policyIDs = append(policyIDs, policyID)
+		sort.Ints(policyIDs)
+	}
+
+	links["policy_ids"] = policyIDs
+}
+
+//
+// Infrastructure
+//
+
+var testFakeInfraConditionPath = regexp.MustCompile(`^alerts/conditions/(\d+)$`)
+
+func (s *testFakeServer) serveInfrastructure(w http.ResponseWriter, r *http.Request, p string) bool {
+	if p == "alerts/conditions" {
+		switch r.Method {
+		case http.MethodGet:
+			policyID, _ := strconv.Atoi(r.URL.Query().Get("policy_id"))
+
+			conditions := []testFakeObject{}
+			for _, id := range testFakeSortedIDs(s.infraConditions) {
+				if int(s.infraConditions[id]["policy_id"].(float64)) == policyID {
+					conditions = append(conditions, s.infraConditions[id])
+				}
+			}
+
+			s.write(w, http.StatusOK, testFakeObject{"data": conditions})
+		case http.MethodPost:
+			condition := s.decodeObject(r, "data")
+			if _, ok := s.policies[int(condition["policy_id"].(float64))]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return true
+			}
+
+			condition["id"] = s.nextID()
+			s.infraConditions[condition["id"].(int)] = condition
+
+			s.write(w, http.StatusCreated, testFakeObject{"data": condition})
+		default:
+			return false
+		}
+
+		return true
+	}
+
+	if !testFakeInfraConditionPath.MatchString(p) {
+		return false
+	}
+
+	id, _ := strconv.Atoi(testFakeInfraConditionPath.FindStringSubmatch(p)[1])
+
+	existing, ok := s.infraConditions[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return true
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.write(w, http.StatusOK, testFakeObject{"data": existing})
+	case http.MethodPut:
+		condition := s.decodeObject(r, "data")
+		condition["id"] = id
+		s.infraConditions[id] = condition
+
+		s.write(w, http.StatusOK, testFakeObject{"data": condition})
+	case http.MethodDelete:
+		delete(s.infraConditions, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		return false
+	}
+
+	return true
+}
+
+//
+// Synthetics
+//
+
+var testFakeMonitorPath = regexp.MustCompile(`^v4/monitors/([\w-]+)$`)
+
+func (s *testFakeServer) serveSynthetics(w http.ResponseWriter, r *http.Request, p string) bool {
+	if p == "v4/monitors" {
+		switch r.Method {
+		case http.MethodGet:
+			ids := make([]string, 0, len(s.monitors))
+			for id := range s.monitors {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+
+			monitors := []testFakeObject{}
+			for _, id := range ids {
+				monitors = append(monitors, s.monitors[id])
+			}
+
+			s.write(w, http.StatusOK, testFakeObject{"monitors": monitors, "count": len(monitors)})
+		case http.MethodPost:
+			monitor := s.decodeObject(r, "")
+			id := fmt.Sprintf("00000000-0000-4000-8000-%012d", s.nextID())
+			monitor["id"] = id
+			s.monitors[id] = monitor
+
+			w.Header().Set("Location", fmt.Sprintf("%s/synthetics/v4/monitors/%s", s.URL, id))
+			w.WriteHeader(http.StatusCreated)
+		default:
+			return false
+		}
+
+		return true
+	}
+
+	if !testFakeMonitorPath.MatchString(p) {
+		return false
+	}
+
+	id := testFakeMonitorPath.FindStringSubmatch(p)[1]
+
+	existing, ok := s.monitors[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return true
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.write(w, http.StatusOK, existing)
+	case http.MethodPut:
+		monitor := s.decodeObject(r, "")
+		monitor["id"] = id
+		s.monitors[id] = monitor
+
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		delete(s.monitors, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		return false
+	}
+
+	return true
+}
+
+//
+// NerdGraph
+//
+
+// testFakeNerdGraphOperations maps the fields queried or mutated by
+// newrelic-client-go to their handlers, in the order they are matched.
+var testFakeNerdGraphOperations = []struct {
+	field   string
+	handler func(s *testFakeServer, vars testFakeObject) (interface{}, bool)
+}{
+	{"alertsPolicyCreate(", (*testFakeServer).nerdGraphPolicyCreate},
+	{"alertsPolicyUpdate(", (*testFakeServer).nerdGraphPolicyUpdate},
+	{"alertsPolicyDelete(", (*testFakeServer).nerdGraphPolicyDelete},
+	{"policiesSearch(", (*testFakeServer).nerdGraphPoliciesSearch},
+	{"policy(id:", (*testFakeServer).nerdGraphPolicy},
+	{"notificationChannel(id:", (*testFakeServer).nerdGraphNotificationChannel},
+	{"alertsNotificationChannelUpdate(", (*testFakeServer).nerdGraphNotificationChannelUpdate},
+	{"alertsNrqlConditionBaselineCreate(", nerdGraphNrqlConditionCreate("BASELINE")},
+	{"alertsNrqlConditionStaticCreate(", nerdGraphNrqlConditionCreate("STATIC")},
+	{"alertsNrqlConditionOutlierCreate(", nerdGraphNrqlConditionCreate("OUTLIER")},
+	{"alertsNrqlConditionBaselineUpdate(", nerdGraphNrqlConditionUpdate("BASELINE")},
+	{"alertsNrqlConditionStaticUpdate(", nerdGraphNrqlConditionUpdate("STATIC")},
+	{"alertsNrqlConditionOutlierUpdate(", nerdGraphNrqlConditionUpdate("OUTLIER")},
+	{"alertsConditionDelete(", (*testFakeServer).nerdGraphConditionDelete},
+	{"nrqlConditionsSearch(", (*testFakeServer).nerdGraphNrqlConditionsSearch},
+	{"nrqlCondition(id:", (*testFakeServer).nerdGraphNrqlCondition},
+	{"aiNotificationsCreateDestination(", (*testFakeServer).nerdGraphCreateDestination},
+	{"aiNotificationsUpdateDestination(", (*testFakeServer).nerdGraphUpdateDestination},
+	{"aiNotificationsDeleteDestination(", (*testFakeServer).nerdGraphDeleteDestination},
+	{"destinations(filters:", (*testFakeServer).nerdGraphDestinations},
+	{"aiNotificationsCreateChannel(", (*testFakeServer).nerdGraphCreateChannel},
+	{"aiNotificationsUpdateChannel(", (*testFakeServer).nerdGraphUpdateChannel},
+	{"aiNotificationsDeleteChannel(", (*testFakeServer).nerdGraphDeleteChannel},
+	{"channels(filters:", (*testFakeServer).nerdGraphChannels},
+	{"aiWorkflowsCreateWorkflow(", (*testFakeServer).nerdGraphCreateWorkflow},
+	{"aiWorkflowsUpdateWorkflow(", (*testFakeServer).nerdGraphUpdateWorkflow},
+	{"aiWorkflowsDeleteWorkflow(", (*testFakeServer).nerdGraphDeleteWorkflow},
+	{"workflows(filters:", (*testFakeServer).nerdGraphWorkflows},
+	{"dashboardCreate(", (*testFakeServer).nerdGraphDashboardCreate},
+	{"dashboardUpdate(", (*testFakeServer).nerdGraphDashboardUpdate},
+	{"dashboardDelete(", (*testFakeServer).nerdGraphDashboardDelete},
+	{"... on DashboardEntity", (*testFakeServer).nerdGraphDashboard},
+	{"taggingAddTagsToEntity(", (*testFakeServer).nerdGraphAddTags},
+	{"taggingReplaceTagsOnEntity(", (*testFakeServer).nerdGraphReplaceTags},
+	{"taggingDeleteTagFromEntity(", (*testFakeServer).nerdGraphDeleteTags},
+	{"tagsWithMetadata", (*testFakeServer).nerdGraphTags},
+}
+
+func (s *testFakeServer) serveNerdGraph(w http.ResponseWriter, r *http.Request) bool {
+	var req struct {
+		Query     string         `json:"query"`
+		Variables testFakeObject `json:"variables"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.t.Errorf("fake server: decoding NerdGraph request: %s", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return true
+	}
+
+	query := strings.Join(strings.Fields(req.Query), " ")
+
+	for _, op := range testFakeNerdGraphOperations {
+		if !strings.Contains(query, op.field) {
+			continue
+		}
+
+		if account, ok := req.Variables["accountId"]; ok && int(account.(float64)) != testFakeAccountID {
+			s.write(w, http.StatusOK, testFakeNerdGraphError("Access denied"))
+			return true
+		}
+
+		if account, ok := req.Variables["accountID"]; ok && int(account.(float64)) != testFakeAccountID {
+			s.write(w, http.StatusOK, testFakeNerdGraphError("Access denied"))
+			return true
+		}
+
+		data, found := op.handler(s, req.Variables)
+		if !found {
+			s.write(w, http.StatusOK, testFakeNerdGraphNotFound())
+			return true
+		}
+
+		s.write(w, http.StatusOK, testFakeObject{"data": data})
+
+		return true
+	}
+
+	return false
+}
+
+func (s *testFakeServer) nerdGraphPolicyCreate(vars testFakeObject) (interface{}, bool) {
+	input := vars["policy"].(testFakeObject)
+	id := s.nextID()
+
+	policy := testFakeObject{
+		"id":                 strconv.Itoa(id),
+		"accountId":          testFakeAccountID,
+		"name":               input["name"],
+		"incidentPreference": input["incidentPreference"],
+	}
+	s.policies[id] = policy
+
+	return testFakeObject{"alertsPolicyCreate": policy}, true
+}
+
+func (s *testFakeServer) nerdGraphPolicyUpdate(vars testFakeObject) (interface{}, bool) {
+	policy, ok := s.policies[testFakeVarID(vars, "policyID")]
+	if !ok {
+		return nil, false
+	}
+
+	for k, v := range vars["policy"].(testFakeObject) {
+		policy[k] = v
+	}
+
+	return testFakeObject{"alertsPolicyUpdate": policy}, true
+}
+
+func (s *testFakeServer) nerdGraphPolicyDelete(vars testFakeObject) (interface{}, bool) {
+	id := testFakeVarID(vars, "policyID")
+	if _, ok := s.policies[id]; !ok {
+		return nil, false
+	}
+
+	// Deleting a policy deletes its conditions and tags, and detaches its
+	// channels.
+	delete(s.policies, id)
+	delete(s.tags, string(alertPolicyGUID(testFakeAccountID, strconv.Itoa(id))))
+
+	for conditionID, c := range s.conditions {
+		if c.policyID == id {
+			delete(s.conditions, conditionID)
+		}
+	}
+
+	for conditionID, c := range s.infraConditions {
+		if int(c["policy_id"].(float64)) == id {
+			delete(s.infraConditions, conditionID)
+		}
+	}
+
+	for conditionID, c := range s.nrqlConditions {
+		if c["policyId"] == strconv.Itoa(id) {
+			delete(s.nrqlConditions, conditionID)
+		}
+	}
+
+	for channelID := range s.channels {
+		s.linkChannel(channelID, id, false)
+	}
+
+	return testFakeObject{"alertsPolicyDelete": testFakeObject{"id": strconv.Itoa(id)}}, true
+}
+
+func (s *testFakeServer) nerdGraphPolicy(vars testFakeObject) (interface{}, bool) {
+	policy, ok := s.policies[testFakeVarID(vars, "policyID")]
+	if !ok {
+		return nil, false
+	}
+
+	return testFakeNerdGraphAlerts(testFakeObject{"policy": policy}), true
+}
+
+func (s *testFakeServer) nerdGraphNotificationChannel(vars testFakeObject) (interface{}, bool) {
+	id := testFakeVarID(vars, "channelId")
+	if _, ok := s.channels[id]; !ok {
+		return testFakeNerdGraphAlerts(testFakeObject{"notificationChannel": nil}), true
+	}
+
+	policies := []testFakeObject{}
+	for _, policyID := range s.channels[id]["links"].(testFakeObject)["policy_ids"].([]int) {
+		policies = append(policies, testFakeObject{"id": strconv.Itoa(policyID)})
+	}
+
+	return testFakeNerdGraphAlerts(testFakeObject{"notificationChannel": testFakeObject{
+		"id":                 strconv.Itoa(id),
+		"associatedPolicies": testFakeObject{"policies": policies},
+	}}), true
+}
+
+// nerdGraphNotificationChannelUpdate updates a channel of the REST API, turning
+// the input of its type back into the REST configuration.
+func (s *testFakeServer) nerdGraphNotificationChannelUpdate(vars testFakeObject) (interface{}, bool) {
+	id := testFakeVarID(vars, "channelId")
+	channel, ok := s.channels[id]
+	if !ok {
+		return nil, false
+	}
+
+	join := func(v interface{}) string {
+		values := []string{}
+		for _, value := range testFakeList(v) {
+			values = append(values, value.(string))
+		}
+		return strings.Join(values, ",")
+	}
+
+	for channelType, raw := range vars["channel"].(testFakeObject) {
+		input := raw.(testFakeObject)
+		config := testFakeObject{}
+
+		switch channelType {
+		case "email":
+			config["recipients"] = join(input["emails"])
+			if input["includeJson"] == true {
+				config["include_json_attachment"] = "1"
+			}
+		case "slack":
+			config["url"] = input["url"]
+			config["channel"] = input["teamChannel"]
+		case "webhook":
+			config["base_url"] = input["baseUrl"]
+			if auth, ok := input["basicAuth"].(testFakeObject); ok {
+				config["auth_username"] = auth["username"]
+				config["auth_password"] = auth["password"]
+			}
+
+			headers := testFakeObject{}
+			for _, header := range testFakeList(input["customHttpHeaders"]) {
+				headers[header.(testFakeObject)["name"].(string)] = header.(testFakeObject)["value"]
+			}
+			if len(headers) > 0 {
+				config["headers"] = headers
+			}
+
+			if body, ok := input["customPayloadBody"].(string); ok {
+				var payload testFakeObject
+				if err := json.Unmarshal([]byte(body), &payload); err != nil {
+					s.t.Errorf("fake server: decoding webhook payload: %s", err)
+				}
+
+				config["payload"] = payload
+				config["payload_type"] = map[string]string{"JSON": "application/json", "FORM": "application/x-www-form-urlencoded"}[input["customPayloadType"].(string)]
+			}
+		default:
+			s.t.Errorf("fake server: unsupported channel update of type %s", channelType)
+		}
+
+		channel["name"] = input["name"]
+		channel["configuration"] = config
+	}
+
+	return testFakeObject{"alertsNotificationChannelUpdate": testFakeObject{
+		"notificationChannel": testFakeObject{"id": strconv.Itoa(id)},
+		"error":               nil,
+	}}, true
+}
+
+func (s *testFakeServer) nerdGraphPoliciesSearch(vars testFakeObject) (interface{}, bool) {
+	var ids []interface{}
+	if criteria, ok := vars["searchCriteria"].(testFakeObject); ok {
+		ids, _ = criteria["ids"].([]interface{})
+	}
+
+	policies := []testFakeObject{}
+	for _, id := range testFakeSortedIDs(s.policies) {
+		if len(ids) == 0 || testFakeHasValue(ids, strconv.Itoa(id)) {
+			policies = append(policies, s.policies[id])
+		}
+	}
+
+	return testFakeNerdGraphAlerts(testFakeObject{"policiesSearch": testFakeObject{
+		"nextCursor": nil,
+		"totalCount": len(policies),
+		"policies":   policies,
+	}}), true
+}
+
+func nerdGraphNrqlConditionCreate(conditionType string) func(*testFakeServer, testFakeObject) (interface{}, bool) {
+	return func(s *testFakeServer, vars testFakeObject) (interface{}, bool) {
+		policyID := vars["policyId"].(string)
+		if _, ok := s.policies[testFakeVarID(vars, "policyId")]; !ok {
+			return nil, false
+		}
+
+		id := s.nextID()
+		condition := vars["condition"].(testFakeObject)
+		condition["id"] = strconv.Itoa(id)
+		condition["policyId"] = policyID
+		testFakeNrqlConditionDefaults(conditionType, condition)
+		s.nrqlConditions[id] = condition
+
+		field := "alertsNrqlCondition" + nrqlConditionTypeName(conditionType) + "Create"
+
+		return testFakeObject{field: condition}, true
+	}
+}
+
+func nerdGraphNrqlConditionUpdate(conditionType string) func(*testFakeServer, testFakeObject) (interface{}, bool) {
+	return func(s *testFakeServer, vars testFakeObject) (interface{}, bool) {
+		id := testFakeVarID(vars, "id")
+
+		existing, ok := s.nrqlConditions[id]
+		if !ok || existing["type"] != conditionType {
+			return nil, false
+		}
+
+		condition := vars["condition"].(testFakeObject)
+		condition["id"] = existing["id"]
+		condition["policyId"] = existing["policyId"]
+		testFakeNrqlConditionDefaults(conditionType, condition)
+		s.nrqlConditions[id] = condition
+
+		field := "alertsNrqlCondition" + nrqlConditionTypeName(conditionType) + "Update"
+
+		return testFakeObject{field: condition}, true
+	}
+}
+
+// testFakeNrqlConditionDefaults fills in what NerdGraph returns for attributes
+// left out of a NRQL condition input.
+func testFakeNrqlConditionDefaults(conditionType string, condition testFakeObject) {
+	condition["type"] = conditionType
+
+	switch conditionType {
+	case "BASELINE":
+		if _, ok := condition["baselineDirection"]; !ok {
+			condition["baselineDirection"] = "UPPER_ONLY"
+		}
+	case "STATIC":
+		if _, ok := condition["valueFunction"]; !ok {
+			condition["valueFunction"] = "SINGLE_VALUE"
+		}
+	case "OUTLIER":
+		if _, ok := condition["expectedGroups"]; !ok {
+			condition["expectedGroups"] = 1
+		}
+		if _, ok := condition["openViolationOnGroupOverlap"]; !ok {
+			condition["openViolationOnGroupOverlap"] = false
+		}
+	}
+
+	// The aggregation of a condition with an evaluation offset is derived from
+	// the offset.
+	if nrql, ok := condition["nrql"].(testFakeObject); ok {
+		if offset, ok := nrql["evaluationOffset"].(float64); ok && offset > 0 {
+			signal, ok := condition["signal"].(testFakeObject)
+			if !ok {
+				signal = testFakeObject{}
+				condition["signal"] = signal
+			}
+
+			signal["aggregationMethod"] = "CADENCE"
+			signal["aggregationDelay"] = offset * 60
+		}
+	}
+
+	// The delay and timer default for the aggregation method.
+	if signal, ok := condition["signal"].(testFakeObject); ok {
+		switch signal["aggregationMethod"] {
+		case "EVENT_FLOW", "CADENCE":
+			if _, ok := signal["aggregationDelay"]; !ok {
+				signal["aggregationDelay"] = 120
+			}
+		case "EVENT_TIMER":
+			if _, ok := signal["aggregationTimer"]; !ok {
+				signal["aggregationTimer"] = 60
+			}
+		}
+	}
+
+	limits := map[string]float64{
+		"ONE_HOUR":          3600,
+		"TWO_HOURS":         7200,
+		"FOUR_HOURS":        14400,
+		"EIGHT_HOURS":       28800,
+		"TWELVE_HOURS":      43200,
+		"TWENTY_FOUR_HOURS": 86400,
+	}
+
+	if limit, ok := condition["violationTimeLimit"].(string); ok {
+		condition["violationTimeLimitSeconds"] = limits[limit]
+	} else if seconds, ok := condition["violationTimeLimitSeconds"].(float64); ok {
+		for limit, s := range limits {
+			if s == seconds {
+				condition["violationTimeLimit"] = limit
+			}
+		}
+	} else {
+		condition["violationTimeLimit"] = "TWENTY_FOUR_HOURS"
+		condition["violationTimeLimitSeconds"] = limits["TWENTY_FOUR_HOURS"]
+	}
+}
+
+func (s *testFakeServer) nerdGraphConditionDelete(vars testFakeObject) (interface{}, bool) {
+	id := testFakeVarID(vars, "id")
+
+	if _, ok := s.nrqlConditions[id]; ok {
+		delete(s.nrqlConditions, id)
+	} else if _, ok := s.conditions[id]; ok {
+		delete(s.conditions, id)
+	} else {
+		return nil, false
+	}
+
+	return testFakeObject{"alertsConditionDelete": testFakeObject{"id": strconv.Itoa(id)}}, true
+}
+
+func (s *testFakeServer) nerdGraphNrqlCondition(vars testFakeObject) (interface{}, bool) {
+	condition, ok := s.nrqlConditions[testFakeVarID(vars, "id")]
+	if !ok {
+		return nil, false
+	}
+
+	return testFakeNerdGraphAlerts(testFakeObject{"nrqlCondition": condition}), true
+}
+
+func (s *testFakeServer) nerdGraphNrqlConditionsSearch(vars testFakeObject) (interface{}, bool) {
+	var policyID interface{}
+	if criteria, ok := vars["searchCriteria"].(testFakeObject); ok {
+		policyID = criteria["policyId"]
+	}
+
+	conditions := []testFakeObject{}
+	for _, id := range testFakeSortedIDs(s.nrqlConditions) {
+		if policyID == nil || s.nrqlConditions[id]["policyId"] == policyID {
+			conditions = append(conditions, s.nrqlConditions[id])
+		}
+	}
+
+	return testFakeNerdGraphAlerts(testFakeObject{"nrqlConditionsSearch": testFakeObject{
+		"nextCursor":     nil,
+		"totalCount":     len(conditions),
+		"nrqlConditions": conditions,
+	}}), true
+}
+
+func (s *testFakeServer) nerdGraphCreateDestination(vars testFakeObject) (interface{}, bool) {
+	input := vars["destination"].(testFakeObject)
+
+	destination := testFakeObject{"id": s.nextUUID(), "type": input["type"]}
+	testFakeUpdateDestination(destination, input)
+	s.destinations[destination["id"].(string)] = destination
+
+	return testFakeObject{"aiNotificationsCreateDestination": testFakeObject{"destination": destination, "error": nil}}, true
+}
+
+func (s *testFakeServer) nerdGraphUpdateDestination(vars testFakeObject) (interface{}, bool) {
+	destination, ok := s.destinations[vars["destinationId"].(string)]
+	if !ok {
+		return testFakeNotificationError("aiNotificationsUpdateDestination", "Destination not found"), true
+	}
+
+	testFakeUpdateDestination(destination, vars["destination"].(testFakeObject))
+
+	return testFakeObject{"aiNotificationsUpdateDestination": testFakeObject{"destination": destination, "error": nil}}, true
+}
+
+// testFakeUpdateDestination applies a destination input, keeping the secrets
+// of its auth to itself as NerdGraph does.
+func testFakeUpdateDestination(destination testFakeObject, input testFakeObject) {
+	destination["name"] = input["name"]
+	destination["active"] = input["active"]
+	destination["properties"] = input["properties"]
+	destination["auth"] = nil
+
+	if auth, ok := input["auth"].(testFakeObject); ok {
+		switch auth["type"] {
+		case "BASIC":
+			destination["auth"] = testFakeObject{"authType": "BASIC", "user": auth["basic"].(testFakeObject)["user"]}
+		case "TOKEN":
+			destination["auth"] = testFakeObject{"authType": "TOKEN", "prefix": auth["token"].(testFakeObject)["prefix"]}
+		}
+	}
+}
+
+func (s *testFakeServer) nerdGraphDeleteDestination(vars testFakeObject) (interface{}, bool) {
+	id := vars["destinationId"].(string)
+	if _, ok := s.destinations[id]; !ok {
+		return testFakeNotificationError("aiNotificationsDeleteDestination", "Destination not found"), true
+	}
+
+	for _, channel := range s.channelsV2 {
+		if channel["destinationId"] == id {
+			return testFakeNotificationError("aiNotificationsDeleteDestination", "Destination is used by a channel"), true
+		}
+	}
+
+	delete(s.destinations, id)
+
+	return testFakeObject{"aiNotificationsDeleteDestination": testFakeObject{"ids": []string{id}, "error": nil}}, true
+}
+
+func (s *testFakeServer) nerdGraphDestinations(vars testFakeObject) (interface{}, bool) {
+	entities := []testFakeObject{}
+	if destination, ok := s.destinations[vars["id"].(string)]; ok {
+		entities = append(entities, destination)
+	}
+
+	return testFakeNerdGraphNotifications(testFakeObject{"destinations": testFakeObject{"entities": entities, "error": nil}}), true
+}
+
+func (s *testFakeServer) nerdGraphCreateChannel(vars testFakeObject) (interface{}, bool) {
+	input := vars["channel"].(testFakeObject)
+
+	if _, ok := s.destinations[input["destinationId"].(string)]; !ok {
+		return testFakeObject{"aiNotificationsCreateChannel": testFakeObject{
+			"channel": nil,
+			"error": testFakeObject{
+				"details": "Invalid channel",
+				"fields":  []testFakeObject{{"field": "destinationId", "message": "destination not found"}},
+			},
+		}}, true
+	}
+
+	channel := testFakeObject{
+		"id":            s.nextUUID(),
+		"type":          input["type"],
+		"destinationId": input["destinationId"],
+		"product":       input["product"],
+	}
+	testFakeUpdateChannel(channel, input)
+	s.channelsV2[channel["id"].(string)] = channel
+
+	return testFakeObject{"aiNotificationsCreateChannel": testFakeObject{"channel": channel, "error": nil}}, true
+}
+
+func (s *testFakeServer) nerdGraphUpdateChannel(vars testFakeObject) (interface{}, bool) {
+	channel, ok := s.channelsV2[vars["channelId"].(string)]
+	if !ok {
+		return testFakeNotificationError("aiNotificationsUpdateChannel", "Channel not found"), true
+	}
+
+	testFakeUpdateChannel(channel, vars["channel"].(testFakeObject))
+
+	return testFakeObject{"aiNotificationsUpdateChannel": testFakeObject{"channel": channel, "error": nil}}, true
+}
+
+func testFakeUpdateChannel(channel testFakeObject, input testFakeObject) {
+	channel["name"] = input["name"]
+	channel["active"] = input["active"]
+	channel["properties"] = input["properties"]
+}
+
+func (s *testFakeServer) nerdGraphDeleteChannel(vars testFakeObject) (interface{}, bool) {
+	id := vars["channelId"].(string)
+	if _, ok := s.channelsV2[id]; !ok {
+		return testFakeNotificationError("aiNotificationsDeleteChannel", "Channel not found"), true
+	}
+
+	for _, workflow := range s.workflows {
+		for _, d := range workflow["destinationConfigurations"].([]testFakeObject) {
+			if d["channelId"] == id {
+				return testFakeNotificationError("aiNotificationsDeleteChannel", "Channel is used by a workflow"), true
+			}
+		}
+	}
+
+	delete(s.channelsV2, id)
+
+	return testFakeObject{"aiNotificationsDeleteChannel": testFakeObject{"ids": []string{id}, "error": nil}}, true
+}
+
+func (s *testFakeServer) nerdGraphChannels(vars testFakeObject) (interface{}, bool) {
+	entities := []testFakeObject{}
+	if channel, ok := s.channelsV2[vars["id"].(string)]; ok {
+		entities = append(entities, channel)
+	}
+
+	return testFakeNerdGraphNotifications(testFakeObject{"channels": testFakeObject{"entities": entities, "error": nil}}), true
+}
+
+func (s *testFakeServer) nerdGraphCreateWorkflow(vars testFakeObject) (interface{}, bool) {
+	input := vars["workflow"].(testFakeObject)
+	workflow := testFakeObject{"id": s.nextUUID()}
+
+	filter := input["issuesFilter"].(testFakeObject)
+	filter["id"] = s.nextUUID()
+
+	if errs := s.updateWorkflow(workflow, input, filter); errs != nil {
+		return testFakeObject{"aiWorkflowsCreateWorkflow": testFakeObject{"workflow": nil, "errors": errs}}, true
+	}
+
+	s.workflows[workflow["id"].(string)] = workflow
+
+	return testFakeObject{"aiWorkflowsCreateWorkflow": testFakeObject{"workflow": workflow, "errors": []testFakeObject{}}}, true
+}
+
+func (s *testFakeServer) nerdGraphUpdateWorkflow(vars testFakeObject) (interface{}, bool) {
+	input := vars["workflow"].(testFakeObject)
+
+	workflow, ok := s.workflows[input["id"].(string)]
+	if !ok {
+		return testFakeObject{"aiWorkflowsUpdateWorkflow": testFakeObject{"workflow": nil, "errors": testFakeWorkflowErrors("Workflow not found")}}, true
+	}
+
+	updated := input["issuesFilter"].(testFakeObject)
+	if updated["id"] != workflow["issuesFilter"].(testFakeObject)["id"] {
+		return testFakeObject{"aiWorkflowsUpdateWorkflow": testFakeObject{"workflow": nil, "errors": testFakeWorkflowErrors("Issues filter not found")}}, true
+	}
+
+	filter := updated["filterInput"].(testFakeObject)
+	filter["id"] = updated["id"]
+
+	if errs := s.updateWorkflow(workflow, input, filter); errs != nil {
+		return testFakeObject{"aiWorkflowsUpdateWorkflow": testFakeObject{"workflow": nil, "errors": errs}}, true
+	}
+
+	return testFakeObject{"aiWorkflowsUpdateWorkflow": testFakeObject{"workflow": workflow, "errors": []testFakeObject{}}}, true
+}
+
+// updateWorkflow applies a workflow input, giving new enrichments an ID and
+// filling in the name and type of the workflow's channels.
+func (s *testFakeServer) updateWorkflow(workflow testFakeObject, input testFakeObject, filter testFakeObject) []testFakeObject {
+	destinations := []testFakeObject{}
+
+	for _, raw := range input["destinationConfigurations"].([]interface{}) {
+		d := raw.(testFakeObject)
+
+		channel, ok := s.channelsV2[d["channelId"].(string)]
+		if !ok {
+			return testFakeWorkflowErrors(fmt.Sprintf("Channel %s not found", d["channelId"]))
+		}
+
+		triggers, ok := d["notificationTriggers"]
+		if !ok {
+			triggers = []string{"ACTIVATED", "ACKNOWLEDGED", "PRIORITY_CHANGED", "CLOSED", "OTHER_UPDATES"}
+		}
+
+		destinations = append(destinations, testFakeObject{
+			"channelId":            d["channelId"],
+			"name":                 channel["name"],
+			"type":                 channel["type"],
+			"notificationTriggers": triggers,
+		})
+	}
+
+	enrichments := []testFakeObject{}
+
+	if input["enrichments"] != nil {
+		for _, raw := range input["enrichments"].(testFakeObject)["nrql"].([]interface{}) {
+			e := raw.(testFakeObject)
+
+			id, ok := e["id"]
+			if !ok {
+				id = s.nextUUID()
+			}
+
+			enrichments = append(enrichments, testFakeObject{
+				"id":             id,
+				"name":           e["name"],
+				"type":           "NRQL",
+				"configurations": e["configuration"],
+			})
+		}
+	}
+
+	for _, key := range []string{"name", "workflowEnabled", "destinationsEnabled", "enrichmentsEnabled", "mutingRulesHandling"} {
+		workflow[key] = input[key]
+	}
+
+	workflow["issuesFilter"] = filter
+	workflow["enrichments"] = enrichments
+	workflow["destinationConfigurations"] = destinations
+
+	return nil
+}
+
+func (s *testFakeServer) nerdGraphDeleteWorkflow(vars testFakeObject) (interface{}, bool) {
+	id := vars["id"].(string)
+	if _, ok := s.workflows[id]; !ok {
+		return testFakeObject{"aiWorkflowsDeleteWorkflow": testFakeObject{"id": nil, "errors": testFakeWorkflowErrors("Workflow not found")}}, true
+	}
+
+	delete(s.workflows, id)
+
+	return testFakeObject{"aiWorkflowsDeleteWorkflow": testFakeObject{"id": id, "errors": []testFakeObject{}}}, true
+}
+
+func (s *testFakeServer) nerdGraphWorkflows(vars testFakeObject) (interface{}, bool) {
+	entities := []testFakeObject{}
+	if workflow, ok := s.workflows[vars["id"].(string)]; ok {
+		entities = append(entities, workflow)
+	}
+
+	return testFakeObject{"actor": testFakeObject{"account": testFakeObject{"aiWorkflows": testFakeObject{
+		"workflows": testFakeObject{"entities": entities},
+	}}}}, true
+}
+
+func (s *testFakeServer) nerdGraphDashboardCreate(vars testFakeObject) (interface{}, bool) {
+	guid := s.nextGUID("VIZ|DASHBOARD")
+
+	dashboard := testFakeObject{
+		"__typename": "DashboardEntity",
+		"guid":       guid,
+		"accountId":  vars["accountId"],
+		"permalink":  "https://one.newrelic.com/redirect/entity/" + guid,
+	}
+	s.updateDashboard(dashboard, vars["dashboard"].(testFakeObject))
+	s.dashboards[guid] = dashboard
+
+	return testFakeObject{"dashboardCreate": testFakeObject{"entityResult": dashboard, "errors": []testFakeObject{}}}, true
+}
+
+func (s *testFakeServer) nerdGraphDashboardUpdate(vars testFakeObject) (interface{}, bool) {
+	dashboard, ok := s.dashboards[vars["guid"].(string)]
+	if !ok {
+		return nil, false
+	}
+
+	s.updateDashboard(dashboard, vars["dashboard"].(testFakeObject))
+
+	return testFakeObject{"dashboardUpdate": testFakeObject{"entityResult": dashboard, "errors": []testFakeObject{}}}, true
+}
+
+// updateDashboard stores the input of a dashboard as NerdGraph returns it,
+// keeping the GUIDs of pages and the IDs of widgets that are given.
+func (s *testFakeServer) updateDashboard(dashboard testFakeObject, input testFakeObject) {
+	dashboard["name"] = input["name"]
+	dashboard["description"] = input["description"]
+	dashboard["permissions"] = input["permissions"]
+	dashboard["variables"] = testFakeList(input["variables"])
+
+	// NerdGraph returns the default replacement strategy of variables.
+	for _, variable := range testFakeList(dashboard["variables"]) {
+		if _, ok := variable.(testFakeObject)["replacementStrategy"]; !ok {
+			variable.(testFakeObject)["replacementStrategy"] = "DEFAULT"
+		}
+	}
+
+	pages := []testFakeObject{}
+	for _, rawPage := range testFakeList(input["pages"]) {
+		pageInput := rawPage.(testFakeObject)
+
+		guid, _ := pageInput["guid"].(string)
+		if guid == "" {
+			guid = s.nextGUID("VIZ|DASHBOARD")
+		}
+
+		widgets := []testFakeObject{}
+		for _, rawWidget := range testFakeList(pageInput["widgets"]) {
+			widgetInput := rawWidget.(testFakeObject)
+
+			id, _ := widgetInput["id"].(string)
+			if id == "" {
+				id = strconv.Itoa(s.nextID())
+			}
+
+			configuration, _ := widgetInput["configuration"].(testFakeObject)
+			if configuration == nil {
+				configuration = testFakeObject{}
+			}
+
+			// Without a visualization, the typed configuration given decides it.
+			visualization, _ := widgetInput["visualization"].(testFakeObject)
+			if visualization == nil || visualization["id"] == nil {
+				visualization = testFakeObject{}
+				for k := range configuration {
+					visualization["id"] = "viz." + k
+				}
+			}
+
+			// NerdGraph adds the platform options of a visualization.
+			if rawConfiguration, ok := widgetInput["rawConfiguration"].(testFakeObject); ok {
+				if _, ok := rawConfiguration["platformOptions"]; !ok {
+					rawConfiguration["platformOptions"] = testFakeObject{"ignoreTimeRange": false}
+				}
+			}
+
+			linkedEntities := []testFakeObject{}
+			for _, linkedGUID := range testFakeList(widgetInput["linkedEntityGuids"]) {
+				linkedEntities = append(linkedEntities, testFakeObject{"__typename": "DashboardEntityOutline", "guid": linkedGUID})
+			}
+
+			widgets = append(widgets, testFakeObject{
+				"id":               id,
+				"title":            widgetInput["title"],
+				"layout":           widgetInput["layout"],
+				"visualization":    visualization,
+				"configuration":    configuration,
+				"rawConfiguration": widgetInput["rawConfiguration"],
+				"linkedEntities":   linkedEntities,
+			})
+		}
+
+		pages = append(pages, testFakeObject{
+			"guid":        guid,
+			"name":        pageInput["name"],
+			"description": pageInput["description"],
+			"widgets":     widgets,
+		})
+	}
+
+	dashboard["pages"] = pages
+}
+
+func (s *testFakeServer) nerdGraphDashboardDelete(vars testFakeObject) (interface{}, bool) {
+	guid := vars["guid"].(string)
+	if _, ok := s.dashboards[guid]; !ok {
+		return nil, false
+	}
+
+	delete(s.dashboards, guid)
+	delete(s.tags, guid)
+
+	return testFakeObject{"dashboardDelete": testFakeObject{"status": "SUCCESS", "errors": []testFakeObject{}}}, true
+}
+
+func (s *testFakeServer) nerdGraphDashboard(vars testFakeObject) (interface{}, bool) {
+	dashboard, ok := s.dashboards[vars["guid"].(string)]
+	if !ok {
+		return nil, false
+	}
+
+	return testFakeObject{"actor": testFakeObject{"entity": dashboard}}, true
+}
+
+func (s *testFakeServer) nerdGraphTags(vars testFakeObject) (interface{}, bool) {
+	entityTags := s.tags[vars["guid"].(string)]
+
+	keys := make([]string, 0, len(entityTags))
+	for k := range entityTags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	tags := []testFakeObject{}
+	tagsWithMetadata := []testFakeObject{}
+
+	for _, k := range keys {
+		values := []testFakeObject{}
+		for _, v := range entityTags[k] {
+			values = append(values, testFakeObject{"mutable": true, "value": v})
+		}
+
+		tags = append(tags, testFakeObject{"key": k, "values": entityTags[k]})
+		tagsWithMetadata = append(tagsWithMetadata, testFakeObject{"key": k, "values": values})
+	}
+
+	return testFakeObject{"actor": testFakeObject{"entity": testFakeObject{
+		"guid":             vars["guid"],
+		"tags":             tags,
+		"tagsWithMetadata": tagsWithMetadata,
+	}}}, true
+}
+
+func (s *testFakeServer) nerdGraphAddTags(vars testFakeObject) (interface{}, bool) {
+	entityTags := s.entityTags(vars["guid"].(string))
+
+	for _, raw := range vars["tags"].([]interface{}) {
+		// newrelic-client-go sends entities.Tag without JSON field names.
+		tag := raw.(testFakeObject)
+		key := testFakeField(tag, "key", "Key").(string)
+
+		for _, v := range testFakeField(tag, "values", "Values").([]interface{}) {
+			if !stringInSlice(entityTags[key], v.(string)) {
+				entityTags[key] = append(entityTags[key], v.(string))
+			}
+		}
+	}
+
+	return testFakeObject{"taggingAddTagsToEntity": testFakeObject{"errors": []interface{}{}}}, true
+}
+
+func (s *testFakeServer) nerdGraphReplaceTags(vars testFakeObject) (interface{}, bool) {
+	guid := vars["guid"].(string)
+	delete(s.tags, guid)
+
+	if _, ok := s.nerdGraphAddTags(vars); !ok {
+		return nil, false
+	}
+
+	return testFakeObject{"taggingReplaceTagsOnEntity": testFakeObject{"errors": []interface{}{}}}, true
+}
+
+func (s *testFakeServer) nerdGraphDeleteTags(vars testFakeObject) (interface{}, bool) {
+	guid := vars["guid"].(string)
+	entityTags := s.entityTags(guid)
+
+	for _, key := range vars["tagKeys"].([]interface{}) {
+		delete(entityTags, key.(string))
+	}
+
+	if len(entityTags) == 0 {
+		delete(s.tags, guid)
+	}
+
+	return testFakeObject{"taggingDeleteTagFromEntity": testFakeObject{"errors": []interface{}{}}}, true
+}
+
+func (s *testFakeServer) entityTags(guid string) map[string][]string {
+	if _, ok := s.tags[guid]; !ok {
+		s.tags[guid] = map[string][]string{}
+	}
+
+	return s.tags[guid]
+}
+
+//
+// Helpers
+//
+
+// decodeObject reads a request body, unwrapping it from key when given.
+func (s *testFakeServer) decodeObject(r *http.Request, key string) testFakeObject {
+	var body testFakeObject
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		s.t.Errorf("fake server: decoding %s %s: %s", r.Method, r.URL, err)
+		return testFakeObject{}
+	}
+
+	if key == "" {
+		return body
+	}
+
+	object, ok := body[key].(testFakeObject)
+	if !ok {
+		s.t.Errorf("fake server: %s %s is missing %q", r.Method, r.URL, key)
+		return testFakeObject{}
+	}
+
+	return object
+}
+
+func (s *testFakeServer) write(w http.ResponseWriter, status int, body interface{}) {
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		s.t.Errorf("fake server: encoding response: %s", err)
+	}
+}
+
+func testFakeNerdGraphAlerts(alerts testFakeObject) testFakeObject {
+	return testFakeObject{"actor": testFakeObject{"account": testFakeObject{"alerts": alerts}}}
+}
+
+func testFakeNerdGraphNotifications(notifications testFakeObject) testFakeObject {
+	return testFakeObject{"actor": testFakeObject{"account": testFakeObject{"aiNotifications": notifications}}}
+}
+
+// testFakeNotificationError is the payload of a notification mutation that
+// failed.
+func testFakeNotificationError(field string, description string) testFakeObject {
+	return testFakeObject{field: testFakeObject{
+		"error": testFakeObject{"description": description, "type": "INVALID_PARAMETER"},
+	}}
+}
+
+func testFakeWorkflowErrors(description string) []testFakeObject {
+	return []testFakeObject{{"description": description, "type": "INVALID_PARAMETER"}}
+}
+
+func testFakeNerdGraphError(message string) testFakeObject {
+	return testFakeObject{
+		"data":   nil,
+		"errors": []testFakeObject{{"message": message}},
+	}
+}
+
+// testFakeNerdGraphNotFound is the error NerdGraph returns for missing alerts
+// objects, which newrelic-client-go turns into an errors.NotFound.
+func testFakeNerdGraphNotFound() testFakeObject {
+	return testFakeObject{
+		"data": nil,
+		"errors": []testFakeObject{{
+			"message": "Not Found",
+			"downstreamResponse": []testFakeObject{{
+				"message":    "Not Found",
+				"extensions": testFakeObject{"code": "BAD_USER_INPUT"},
+			}},
+		}},
+	}
+}
+
+// testFakeField returns the first of the given fields set on an object.
+func testFakeField(object testFakeObject, names ...string) interface{} {
+	for _, name := range names {
+		if v, ok := object[name]; ok {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// testFakeVarID reads an ID variable, which NerdGraph takes as a string.
+func testFakeVarID(vars testFakeObject, name string) int {
+	switch v := vars[name].(type) {
+	case string:
+		id, _ := strconv.Atoi(v)
+		return id
+	case float64:
+		return int(v)
+	}
+
+	return 0
+}
+
+// testFakeList returns a decoded JSON array, or nil for null.
+func testFakeList(v interface{}) []interface{} {
+	list, _ := v.([]interface{})
+	return list
+}
+
+func testFakeSortedIDs(m interface{}) []int {
+	var ids []int
+
+	switch objects := m.(type) {
+	case map[int]testFakeObject:
+		for id := range objects {
+			ids = append(ids, id)
+		}
+	case map[int]*testFakeCondition:
+		for id := range objects {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Ints(ids)
+
+	return ids
+}
+
+func testFakeHasInt(slice []int, value int) bool {
+	for _, v := range slice {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func testFakeHasValue(slice []interface{}, value interface{}) bool {
+	for _, v := range slice {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -103,3 +103,13 @@ func stringInSlice(slice []string, str string) bool {
 
 	return false
 }
+
+func intSliceContains(slice []int, i int) bool {
+	for _, s := range slice {
+		if i == s {
+			return true
+		}
+	}
+
+	return false
+}
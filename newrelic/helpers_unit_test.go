@@ -62,3 +62,9 @@ func TestSortIntegerSlice(t *testing.T) {
 
 	require.Equal(t, expected, integers)
 }
+
+func TestIntSliceContains(t *testing.T) {
+	require.True(t, intSliceContains([]int{1, 2, 3}, 2))
+	require.False(t, intSliceContains([]int{1, 2, 3}, 4))
+	require.False(t, intSliceContains(nil, 1))
+}
@@ -0,0 +1,115 @@
+package newrelic
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	insights "github.com/newrelic/go-insights/client"
+)
+
+// go-insights sends its requests with the default HTTP client, which has none
+// of the proxy and TLS settings of the provider, and offers no way to supply
+// another. The Insights clients below keep the configuration of go-insights
+// and send the requests with an HTTP client of their own.
+
+// insightsInsertClient posts events to Insights.
+type insightsInsertClient struct {
+	*insights.InsertClient
+	httpClient *http.Client
+}
+
+// PostEvent posts one or more events, gzipped.
+func (c *insightsInsertClient) PostEvent(data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("error marshaling event data: %s", err)
+	}
+
+	var body bytes.Buffer
+	w := gzip.NewWriter(&body)
+	if _, err := w.Write(payload); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.URL.String(), &body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+	req.Header.Set("X-Insert-Key", c.InsertKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed insert request: %s", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("bad response from Insights: %d: %s", resp.StatusCode, respBody)
+	}
+
+	var result struct {
+		Error   string `json:"error"`
+		Success bool   `json:"success"`
+	}
+
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return fmt.Errorf("failed to unmarshal Insights response: %s", err)
+	}
+
+	if !result.Success {
+		return fmt.Errorf("error posting events to Insights: %s", result.Error)
+	}
+
+	return nil
+}
+
+// insightsQueryClient runs NRQL queries through Insights.
+type insightsQueryClient struct {
+	*insights.QueryClient
+	httpClient *http.Client
+}
+
+// QueryEvents runs a NRQL query.
+func (c *insightsQueryClient) QueryEvents(nrql string) (*insights.QueryResponse, error) {
+	req, err := http.NewRequest(http.MethodGet, c.URL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.URL.RawQuery = url.Values{"nrql": []string{nrql}}.Encode()
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Query-Key", c.QueryKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed query request: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bad response code: %d", resp.StatusCode)
+	}
+
+	var response insights.QueryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal query response: %s", err)
+	}
+
+	return &response, nil
+}
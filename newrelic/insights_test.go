@@ -0,0 +1,102 @@
+// +build unit
+
+package newrelic
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsightsInsertClient_PostEvent(t *testing.T) {
+	defaultTransport := http.DefaultTransport
+
+	// The server stands in for a proxy, which the provider settings route the
+	// Insights requests through.
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "insights-collector.newrelic.com", r.URL.Host)
+		assert.Equal(t, "/v1/accounts/1234/events", r.URL.Path)
+		assert.Equal(t, "NRII-TEST", r.Header.Get("X-Insert-Key"))
+		assert.Equal(t, "gzip", r.Header.Get("Content-Encoding"))
+
+		body, err := gzip.NewReader(r.Body)
+		require.NoError(t, err)
+		payload, err := ioutil.ReadAll(body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{"eventType":"Deployment","version":"1.2.3"}]`, string(payload))
+
+		fmt.Fprint(w, `{"success":true}`)
+	}))
+	defer proxy.Close()
+
+	cfg := Config{
+		InsightsAccountID: "1234",
+		InsightsInsertKey: "NRII-TEST",
+		InsightsInsertURL: "http://insights-collector.newrelic.com/v1/accounts",
+		ProxyURL:          proxy.URL,
+	}
+
+	client, err := cfg.ClientInsightsInsert()
+	require.NoError(t, err)
+
+	err = client.PostEvent([]map[string]string{{"eventType": "Deployment", "version": "1.2.3"}})
+	require.NoError(t, err)
+
+	assert.Same(t, defaultTransport, http.DefaultTransport, "the default transport must be left untouched")
+}
+
+func TestInsightsInsertClient_PostEventError(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error":"invalid key"}`)
+	}))
+	defer proxy.Close()
+
+	cfg := Config{
+		InsightsAccountID: "1234",
+		InsightsInsertKey: "NRII-TEST",
+		InsightsInsertURL: "http://insights-collector.newrelic.com/v1/accounts",
+		ProxyURL:          proxy.URL,
+	}
+
+	client, err := cfg.ClientInsightsInsert()
+	require.NoError(t, err)
+
+	err = client.PostEvent([]map[string]string{{"eventType": "Deployment"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bad response from Insights: 403")
+}
+
+func TestInsightsQueryClient_QueryEvents(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "insights-api.newrelic.com", r.URL.Host)
+		assert.Equal(t, "/v1/accounts/1234/query", r.URL.Path)
+		assert.Equal(t, "NRIQ-TEST", r.Header.Get("X-Query-Key"))
+		assert.Equal(t, "SELECT count(*) FROM Transaction", r.URL.Query().Get("nrql"))
+
+		fmt.Fprint(w, `{"results":[{"count":42}],"metadata":{}}`)
+	}))
+	defer proxy.Close()
+
+	cfg := Config{
+		InsightsAccountID: "1234",
+		InsightsQueryKey:  "NRIQ-TEST",
+		InsightsQueryURL:  "http://insights-api.newrelic.com/v1/accounts",
+		ProxyURL:          proxy.URL,
+	}
+
+	client, err := cfg.ClientInsightsQuery()
+	require.NoError(t, err)
+
+	resp, err := client.QueryEvents("SELECT count(*) FROM Transaction")
+	require.NoError(t, err)
+
+	require.Len(t, resp.Results, 1)
+	assert.Equal(t, float64(42), resp.Results[0]["count"])
+}
@@ -0,0 +1,213 @@
+package newrelic
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	nrErrors "github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+// The REST API can only list every alert channel of an account, page by page,
+// to find the channels attached to a policy. The policies of a given channel
+// are read from NerdGraph instead, so that refreshing a policy only costs a
+// request per channel managed by Terraform.
+
+const getAlertChannelPoliciesQuery = `
+	query($accountId: Int!, $channelId: ID!) {
+		actor {
+			account(id: $accountId) {
+				alerts {
+					notificationChannel(id: $channelId) {
+						id
+						associatedPolicies {
+							policies {
+								id
+							}
+						}
+					}
+				}
+			}
+		}
+	}`
+
+// getAlertChannelPolicyIDs returns the IDs of the policies an alert channel is
+// attached to, or a *errors.NotFound error when there is no channel with the
+// given ID.
+func getAlertChannelPolicyIDs(client *newrelic.NewRelic, accountID int, channelID int) ([]int, error) {
+	var resp struct {
+		Actor struct {
+			Account struct {
+				Alerts struct {
+					NotificationChannel *struct {
+						ID                 string `json:"id"`
+						AssociatedPolicies struct {
+							Policies []struct {
+								ID string `json:"id"`
+							} `json:"policies"`
+						} `json:"associatedPolicies"`
+					} `json:"notificationChannel"`
+				} `json:"alerts"`
+			} `json:"account"`
+		} `json:"actor"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"channelId": strconv.Itoa(channelID),
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(getAlertChannelPoliciesQuery, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	channel := resp.Actor.Account.Alerts.NotificationChannel
+	if channel == nil {
+		return nil, nrErrors.NewNotFoundf("alert channel %d not found", channelID)
+	}
+
+	policyIDs := []int{}
+	for _, policy := range channel.AssociatedPolicies.Policies {
+		id, err := strconv.Atoi(policy.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		policyIDs = append(policyIDs, id)
+	}
+
+	return policyIDs, nil
+}
+
+// findAttachedChannelIDs returns the channels among the given ones that are
+// attached to a policy, in the given order. Channels that no longer exist are
+// left out.
+func findAttachedChannelIDs(client *newrelic.NewRelic, accountID int, policyID int, channelIDs []int) ([]int, error) {
+	attached := []int{}
+
+	for _, channelID := range channelIDs {
+		policyIDs, err := getAlertChannelPolicyIDs(client, accountID, channelID)
+		if err != nil {
+			if _, ok := err.(*nrErrors.NotFound); ok {
+				continue
+			}
+
+			return nil, err
+		}
+
+		if intSliceContains(policyIDs, policyID) {
+			attached = append(attached, channelID)
+		}
+	}
+
+	return attached, nil
+}
+
+// The REST API cannot update alert channels, and replacing a channel detaches
+// it from the policies it was attached to outside of Terraform. Channels are
+// updated in place with the NerdGraph mutation below instead, which takes the
+// configuration of the channel's type.
+
+// alertChannelUpdateInput is the input of the alert channel update mutation,
+// where only the field of the channel's type is set.
+type alertChannelUpdateInput struct {
+	Email     *alertEmailChannelUpdateInput     `json:"email,omitempty"`
+	OpsGenie  *alertOpsGenieChannelUpdateInput  `json:"opsGenie,omitempty"`
+	PagerDuty *alertPagerDutyChannelUpdateInput `json:"pagerDuty,omitempty"`
+	Slack     *alertSlackChannelUpdateInput     `json:"slack,omitempty"`
+	VictorOps *alertVictorOpsChannelUpdateInput `json:"victorOps,omitempty"`
+	Webhook   *alertWebhookChannelUpdateInput   `json:"webhook,omitempty"`
+}
+
+type alertEmailChannelUpdateInput struct {
+	Name        string   `json:"name"`
+	Emails      []string `json:"emails"`
+	IncludeJSON bool     `json:"includeJson"`
+}
+
+type alertOpsGenieChannelUpdateInput struct {
+	Name             string   `json:"name"`
+	APIKey           string   `json:"apiKey,omitempty"`
+	DataCenterRegion string   `json:"dataCenterRegion,omitempty"`
+	Recipients       []string `json:"recipients"`
+	Tags             []string `json:"tags"`
+	Teams            []string `json:"teams"`
+}
+
+type alertPagerDutyChannelUpdateInput struct {
+	Name   string `json:"name"`
+	APIKey string `json:"apiKey,omitempty"`
+}
+
+type alertSlackChannelUpdateInput struct {
+	Name        string `json:"name"`
+	URL         string `json:"url,omitempty"`
+	TeamChannel string `json:"teamChannel"`
+}
+
+type alertVictorOpsChannelUpdateInput struct {
+	Name     string `json:"name"`
+	Key      string `json:"key,omitempty"`
+	RouteKey string `json:"routeKey,omitempty"`
+}
+
+type alertWebhookChannelUpdateInput struct {
+	Name              string                                `json:"name"`
+	BaseURL           string                                `json:"baseUrl"`
+	BasicAuth         *alertWebhookBasicAuthUpdateInput     `json:"basicAuth"`
+	CustomHTTPHeaders []alertWebhookCustomHeaderUpdateInput `json:"customHttpHeaders"`
+	CustomPayloadBody string                                `json:"customPayloadBody,omitempty"`
+	CustomPayloadType string                                `json:"customPayloadType,omitempty"`
+}
+
+type alertWebhookBasicAuthUpdateInput struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type alertWebhookCustomHeaderUpdateInput struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+const updateAlertChannelMutation = `
+	mutation($accountId: Int!, $channelId: ID!, $channel: AlertsNotificationChannelUpdateInput!) {
+		alertsNotificationChannelUpdate(accountId: $accountId, id: $channelId, notificationChannel: $channel) {
+			notificationChannel {
+				id
+			}
+			error {
+				description
+				errorType
+			}
+		}
+	}`
+
+// updateAlertChannel updates an alert channel in place, keeping the policies
+// it is attached to.
+func updateAlertChannel(client *newrelic.NewRelic, accountID int, channelID int, input *alertChannelUpdateInput) error {
+	var resp struct {
+		AlertsNotificationChannelUpdate struct {
+			Error *struct {
+				Description string `json:"description"`
+				ErrorType   string `json:"errorType"`
+			} `json:"error"`
+		} `json:"alertsNotificationChannelUpdate"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"channelId": strconv.Itoa(channelID),
+		"channel":   input,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(updateAlertChannelMutation, vars, &resp); err != nil {
+		return err
+	}
+
+	if e := resp.AlertsNotificationChannelUpdate.Error; e != nil {
+		return fmt.Errorf("updating alert channel %d: %s: %s", channelID, e.ErrorType, e.Description)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package newrelic
+
+import (
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+)
+
+// newrelic-client-go omits the empty fields of a muting rule update, which
+// NerdGraph leaves unchanged, so a schedule or one of its fields could never
+// be removed. Muting rules are updated with the mutation below instead, which
+// sends removed fields as null.
+
+// mutingRuleUpdateInput is the input of the muting rule update mutation.
+type mutingRuleUpdateInput struct {
+	Condition   *alerts.MutingRuleConditionGroup `json:"condition,omitempty"`
+	Description string                           `json:"description"`
+	Enabled     bool                             `json:"enabled"`
+	Name        string                           `json:"name,omitempty"`
+	Schedule    *mutingRuleScheduleUpdateInput   `json:"schedule"`
+}
+
+// mutingRuleScheduleUpdateInput is the schedule of a muting rule update, where
+// null clears a field.
+type mutingRuleScheduleUpdateInput struct {
+	StartTime        *alerts.NaiveDateTime            `json:"startTime"`
+	EndTime          *alerts.NaiveDateTime            `json:"endTime"`
+	TimeZone         string                           `json:"timeZone"`
+	Repeat           *alerts.MutingRuleScheduleRepeat `json:"repeat"`
+	EndRepeat        *alerts.NaiveDateTime            `json:"endRepeat"`
+	RepeatCount      *int                             `json:"repeatCount"`
+	WeeklyRepeatDays *[]alerts.DayOfWeek              `json:"weeklyRepeatDays"`
+}
+
+const updateMutingRuleMutation = `
+	mutation($accountId: Int!, $ruleId: ID!, $rule: AlertsMutingRuleUpdateInput!) {
+		alertsMutingRuleUpdate(accountId: $accountId, id: $ruleId, rule: $rule) {
+			id
+		}
+	}`
+
+// updateMutingRule updates a muting rule, clearing the fields that are null.
+func updateMutingRule(client *newrelic.NewRelic, accountID int, ruleID int, input *mutingRuleUpdateInput) error {
+	var resp struct {
+		AlertsMutingRuleUpdate struct {
+			ID string `json:"id"`
+		} `json:"alertsMutingRuleUpdate"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"ruleId":    ruleID,
+		"rule":      input,
+	}
+
+	return client.NerdGraph.QueryWithResponse(updateMutingRuleMutation, vars, &resp)
+}
@@ -0,0 +1,527 @@
+package newrelic
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	nrErrors "github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+// newrelic-client-go does not cover notification destinations and channels
+// yet, so they are managed with the NerdGraph queries and mutations below.
+
+var notificationDestinationTypes = []string{
+	"EMAIL",
+	"EVENT_BRIDGE",
+	"JIRA",
+	"MOBILE_PUSH",
+	"PAGERDUTY_ACCOUNT_INTEGRATION",
+	"PAGERDUTY_SERVICE_INTEGRATION",
+	"SERVICE_NOW",
+	"SLACK",
+	"SLACK_COLLABORATION",
+	"SLACK_LEGACY",
+	"WEBHOOK",
+}
+
+var notificationChannelTypes = []string{
+	"EMAIL",
+	"EVENT_BRIDGE",
+	"JIRA_CLASSIC",
+	"JIRA_NEXTGEN",
+	"MOBILE_PUSH",
+	"PAGERDUTY_ACCOUNT_INTEGRATION",
+	"PAGERDUTY_SERVICE_INTEGRATION",
+	"SERVICENOW_EVENTS",
+	"SERVICENOW_INCIDENTS",
+	"SLACK",
+	"SLACK_COLLABORATION",
+	"SLACK_LEGACY",
+	"WEBHOOK",
+}
+
+var notificationChannelProducts = []string{
+	"ALERTS",
+	"DISCUSSIONS",
+	"ERROR_TRACKING",
+	"IINT",
+	"NTFC",
+	"PD",
+	"SHARING",
+}
+
+// notificationProperty is a key/value setting of a destination or channel.
+type notificationProperty struct {
+	Key          string `json:"key"`
+	Value        string `json:"value"`
+	Label        string `json:"label,omitempty"`
+	DisplayValue string `json:"displayValue,omitempty"`
+}
+
+type notificationBasicAuthInput struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+}
+
+type notificationTokenAuthInput struct {
+	Prefix string `json:"prefix,omitempty"`
+	Token  string `json:"token"`
+}
+
+type notificationAuthInput struct {
+	Type  string                      `json:"type"`
+	Basic *notificationBasicAuthInput `json:"basic,omitempty"`
+	Token *notificationTokenAuthInput `json:"token,omitempty"`
+}
+
+// notificationDestinationInput is the input of the destination create and
+// update mutations. The type of a destination cannot be updated.
+type notificationDestinationInput struct {
+	Name       string                 `json:"name"`
+	Type       string                 `json:"type,omitempty"`
+	Active     bool                   `json:"active"`
+	Properties []notificationProperty `json:"properties"`
+	Auth       *notificationAuthInput `json:"auth,omitempty"`
+}
+
+// notificationDestinationAuth is the auth of a destination as read from
+// NerdGraph, which never returns passwords or tokens.
+type notificationDestinationAuth struct {
+	AuthType string `json:"authType"`
+	User     string `json:"user,omitempty"`
+	Prefix   string `json:"prefix,omitempty"`
+}
+
+type notificationDestination struct {
+	ID         string                       `json:"id"`
+	Name       string                       `json:"name"`
+	Type       string                       `json:"type"`
+	Active     bool                         `json:"active"`
+	Properties []notificationProperty       `json:"properties"`
+	Auth       *notificationDestinationAuth `json:"auth"`
+}
+
+// notificationChannelInput is the input of the channel create and update
+// mutations. The type, destination and product of a channel cannot be
+// updated.
+type notificationChannelInput struct {
+	Name          string                 `json:"name"`
+	Type          string                 `json:"type,omitempty"`
+	DestinationID string                 `json:"destinationId,omitempty"`
+	Product       string                 `json:"product,omitempty"`
+	Active        bool                   `json:"active"`
+	Properties    []notificationProperty `json:"properties"`
+}
+
+type notificationChannel struct {
+	ID            string                 `json:"id"`
+	Name          string                 `json:"name"`
+	Type          string                 `json:"type"`
+	DestinationID string                 `json:"destinationId"`
+	Product       string                 `json:"product"`
+	Active        bool                   `json:"active"`
+	Properties    []notificationProperty `json:"properties"`
+}
+
+// notificationError is any of the errors returned in the payload of the
+// notification mutations.
+type notificationError struct {
+	Description string `json:"description"`
+	Details     string `json:"details"`
+	Type        string `json:"type"`
+	Fields      []struct {
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	} `json:"fields"`
+}
+
+func (e *notificationError) Error() string {
+	var messages []string
+
+	for _, m := range []string{e.Description, e.Details} {
+		if m != "" {
+			messages = append(messages, m)
+		}
+	}
+
+	for _, f := range e.Fields {
+		messages = append(messages, fmt.Sprintf("%s: %s", f.Field, f.Message))
+	}
+
+	if len(messages) == 0 {
+		return fmt.Sprintf("notification error %s", e.Type)
+	}
+
+	return strings.Join(messages, ", ")
+}
+
+const (
+	graphqlNotificationPropertyFields = `
+		properties {
+			key
+			value
+			label
+			displayValue
+		}`
+
+	graphqlNotificationErrorFields = `
+		error {
+			... on AiNotificationsResponseError {
+				description
+				details
+				type
+			}
+			... on AiNotificationsDataValidationError {
+				details
+				fields {
+					field
+					message
+				}
+			}
+			... on AiNotificationsSuberror {
+				description
+				details
+			}
+		}`
+
+	graphqlNotificationDestinationFields = `
+		id
+		name
+		type
+		active` +
+		graphqlNotificationPropertyFields + `
+		auth {
+			... on AiNotificationsBasicAuth {
+				authType
+				user
+			}
+			... on AiNotificationsTokenAuth {
+				authType
+				prefix
+			}
+		}`
+
+	graphqlNotificationChannelFields = `
+		id
+		name
+		type
+		destinationId
+		product
+		active` +
+		graphqlNotificationPropertyFields
+
+	createNotificationDestinationMutation = `
+		mutation($accountId: Int!, $destination: AiNotificationsDestinationInput!) {
+			aiNotificationsCreateDestination(accountId: $accountId, destination: $destination) {
+				destination {` +
+		graphqlNotificationDestinationFields + `
+				}` +
+		graphqlNotificationErrorFields + `
+			}
+		}`
+
+	updateNotificationDestinationMutation = `
+		mutation($accountId: Int!, $destinationId: ID!, $destination: AiNotificationsDestinationUpdate!) {
+			aiNotificationsUpdateDestination(accountId: $accountId, destinationId: $destinationId, destination: $destination) {
+				destination {` +
+		graphqlNotificationDestinationFields + `
+				}` +
+		graphqlNotificationErrorFields + `
+			}
+		}`
+
+	deleteNotificationDestinationMutation = `
+		mutation($accountId: Int!, $destinationId: ID!) {
+			aiNotificationsDeleteDestination(accountId: $accountId, destinationId: $destinationId) {
+				ids` +
+		graphqlNotificationErrorFields + `
+			}
+		}`
+
+	getNotificationDestinationQuery = `
+		query($accountId: Int!, $id: ID!) {
+			actor {
+				account(id: $accountId) {
+					aiNotifications {
+						destinations(filters: {id: $id}) {
+							entities {` +
+		graphqlNotificationDestinationFields + `
+							}` +
+		graphqlNotificationErrorFields + `
+						}
+					}
+				}
+			}
+		}`
+
+	createNotificationChannelMutation = `
+		mutation($accountId: Int!, $channel: AiNotificationsChannelInput!) {
+			aiNotificationsCreateChannel(accountId: $accountId, channel: $channel) {
+				channel {` +
+		graphqlNotificationChannelFields + `
+				}` +
+		graphqlNotificationErrorFields + `
+			}
+		}`
+
+	updateNotificationChannelMutation = `
+		mutation($accountId: Int!, $channelId: ID!, $channel: AiNotificationsChannelUpdate!) {
+			aiNotificationsUpdateChannel(accountId: $accountId, channelId: $channelId, channel: $channel) {
+				channel {` +
+		graphqlNotificationChannelFields + `
+				}` +
+		graphqlNotificationErrorFields + `
+			}
+		}`
+
+	deleteNotificationChannelMutation = `
+		mutation($accountId: Int!, $channelId: ID!) {
+			aiNotificationsDeleteChannel(accountId: $accountId, channelId: $channelId) {
+				ids` +
+		graphqlNotificationErrorFields + `
+			}
+		}`
+
+	getNotificationChannelQuery = `
+		query($accountId: Int!, $id: ID!) {
+			actor {
+				account(id: $accountId) {
+					aiNotifications {
+						channels(filters: {id: $id}) {
+							entities {` +
+		graphqlNotificationChannelFields + `
+							}` +
+		graphqlNotificationErrorFields + `
+						}
+					}
+				}
+			}
+		}`
+)
+
+type notificationDestinationResponse struct {
+	Destination *notificationDestination `json:"destination"`
+	Error       *notificationError       `json:"error"`
+}
+
+type notificationChannelResponse struct {
+	Channel *notificationChannel `json:"channel"`
+	Error   *notificationError   `json:"error"`
+}
+
+type notificationDeleteResponse struct {
+	IDs   []string           `json:"ids"`
+	Error *notificationError `json:"error"`
+}
+
+// notificationsQuery is the response of the destinations and channels
+// queries.
+type notificationsQuery struct {
+	Actor struct {
+		Account struct {
+			AINotifications struct {
+				Destinations struct {
+					Entities []*notificationDestination `json:"entities"`
+					Error    *notificationError         `json:"error"`
+				} `json:"destinations"`
+				Channels struct {
+					Entities []*notificationChannel `json:"entities"`
+					Error    *notificationError     `json:"error"`
+				} `json:"channels"`
+			} `json:"aiNotifications"`
+		} `json:"account"`
+	} `json:"actor"`
+}
+
+func createNotificationDestination(client *newrelic.NewRelic, accountID int, input *notificationDestinationInput) (*notificationDestination, error) {
+	var resp struct {
+		Result notificationDestinationResponse `json:"aiNotificationsCreateDestination"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId":   accountID,
+		"destination": input,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(createNotificationDestinationMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	return notificationDestinationResult(resp.Result)
+}
+
+func updateNotificationDestination(client *newrelic.NewRelic, accountID int, destinationID string, input *notificationDestinationInput) (*notificationDestination, error) {
+	var resp struct {
+		Result notificationDestinationResponse `json:"aiNotificationsUpdateDestination"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId":     accountID,
+		"destinationId": destinationID,
+		"destination":   input,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(updateNotificationDestinationMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	return notificationDestinationResult(resp.Result)
+}
+
+func notificationDestinationResult(result notificationDestinationResponse) (*notificationDestination, error) {
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.Destination == nil {
+		return nil, fmt.Errorf("no notification destination returned")
+	}
+
+	return result.Destination, nil
+}
+
+func deleteNotificationDestination(client *newrelic.NewRelic, accountID int, destinationID string) error {
+	var resp struct {
+		Result notificationDeleteResponse `json:"aiNotificationsDeleteDestination"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId":     accountID,
+		"destinationId": destinationID,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(deleteNotificationDestinationMutation, vars, &resp); err != nil {
+		return err
+	}
+
+	if resp.Result.Error != nil {
+		return resp.Result.Error
+	}
+
+	return nil
+}
+
+// getNotificationDestination returns a *errors.NotFound error when there is no
+// destination with the given ID.
+func getNotificationDestination(client *newrelic.NewRelic, accountID int, destinationID string) (*notificationDestination, error) {
+	var resp notificationsQuery
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"id":        destinationID,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(getNotificationDestinationQuery, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	destinations := resp.Actor.Account.AINotifications.Destinations
+	if destinations.Error != nil {
+		return nil, destinations.Error
+	}
+
+	for _, destination := range destinations.Entities {
+		if destination.ID == destinationID {
+			return destination, nil
+		}
+	}
+
+	return nil, nrErrors.NewNotFoundf("notification destination %s not found", destinationID)
+}
+
+func createNotificationChannel(client *newrelic.NewRelic, accountID int, input *notificationChannelInput) (*notificationChannel, error) {
+	var resp struct {
+		Result notificationChannelResponse `json:"aiNotificationsCreateChannel"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"channel":   input,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(createNotificationChannelMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	return notificationChannelResult(resp.Result)
+}
+
+func updateNotificationChannel(client *newrelic.NewRelic, accountID int, channelID string, input *notificationChannelInput) (*notificationChannel, error) {
+	var resp struct {
+		Result notificationChannelResponse `json:"aiNotificationsUpdateChannel"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"channelId": channelID,
+		"channel":   input,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(updateNotificationChannelMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	return notificationChannelResult(resp.Result)
+}
+
+func notificationChannelResult(result notificationChannelResponse) (*notificationChannel, error) {
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.Channel == nil {
+		return nil, fmt.Errorf("no notification channel returned")
+	}
+
+	return result.Channel, nil
+}
+
+func deleteNotificationChannel(client *newrelic.NewRelic, accountID int, channelID string) error {
+	var resp struct {
+		Result notificationDeleteResponse `json:"aiNotificationsDeleteChannel"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"channelId": channelID,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(deleteNotificationChannelMutation, vars, &resp); err != nil {
+		return err
+	}
+
+	if resp.Result.Error != nil {
+		return resp.Result.Error
+	}
+
+	return nil
+}
+
+// getNotificationChannel returns a *errors.NotFound error when there is no
+// channel with the given ID.
+func getNotificationChannel(client *newrelic.NewRelic, accountID int, channelID string) (*notificationChannel, error) {
+	var resp notificationsQuery
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"id":        channelID,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(getNotificationChannelQuery, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	channels := resp.Actor.Account.AINotifications.Channels
+	if channels.Error != nil {
+		return nil, channels.Error
+	}
+
+	for _, channel := range channels.Entities {
+		if channel.ID == channelID {
+			return channel, nil
+		}
+	}
+
+	return nil, nrErrors.NewNotFoundf("notification channel %s not found", channelID)
+}
@@ -0,0 +1,185 @@
+package newrelic
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+)
+
+// newrelic-client-go does not model the streaming aggregation options of a
+// NRQL condition's signal yet, so NRQL conditions are written with the
+// mutations below, and the signal is read back separately.
+
+// nrqlConditionSignal is the signal of a NRQL condition, including the
+// aggregation options missing from alerts.AlertsNrqlConditionSignal.
+type nrqlConditionSignal struct {
+	alerts.AlertsNrqlConditionSignal
+
+	AggregationMethod *string `json:"aggregationMethod,omitempty"`
+	AggregationDelay  *int    `json:"aggregationDelay,omitempty"`
+	AggregationTimer  *int    `json:"aggregationTimer,omitempty"`
+	SlideBy           *int    `json:"slideBy,omitempty"`
+}
+
+// nrqlConditionInput is the input of the NRQL condition create and update
+// mutations.
+type nrqlConditionInput struct {
+	alerts.NrqlConditionInput
+
+	Signal *nrqlConditionSignal `json:"signal,omitempty"`
+}
+
+// nrqlAlertCondition is a NRQL condition as read from NerdGraph.
+type nrqlAlertCondition struct {
+	alerts.NrqlAlertCondition
+
+	Signal *nrqlConditionSignal `json:"signal,omitempty"`
+}
+
+// newNrqlAlertCondition wraps a condition returned by newrelic-client-go.
+func newNrqlAlertCondition(condition *alerts.NrqlAlertCondition) *nrqlAlertCondition {
+	c := &nrqlAlertCondition{NrqlAlertCondition: *condition}
+
+	if condition.Signal != nil {
+		c.Signal = &nrqlConditionSignal{AlertsNrqlConditionSignal: *condition.Signal}
+	}
+
+	return c
+}
+
+const (
+	graphqlNrqlConditionSignalFields = `
+		signal {
+			aggregationWindow
+			evaluationOffset
+			fillOption
+			fillValue
+			aggregationMethod
+			aggregationDelay
+			aggregationTimer
+			slideBy
+		}`
+
+	getNrqlConditionSignalQuery = `
+		query($accountId: Int!, $id: ID!) {
+			actor {
+				account(id: $accountId) {
+					alerts {
+						nrqlCondition(id: $id) {` +
+		graphqlNrqlConditionSignalFields +
+		`} } } } }`
+
+	createNrqlConditionMutation = `
+		mutation($accountId: Int!, $policyId: ID!, $condition: AlertsNrqlCondition%[1]sInput!) {
+			alertsNrqlCondition%[1]sCreate(accountId: $accountId, policyId: $policyId, condition: $condition) {
+				id
+			}
+		}`
+
+	updateNrqlConditionMutation = `
+		mutation($accountId: Int!, $id: ID!, $condition: AlertsNrqlConditionUpdate%[1]sInput!) {
+			alertsNrqlCondition%[1]sUpdate(accountId: $accountId, id: $id, condition: $condition) {
+				id
+			}
+		}`
+)
+
+// createNrqlCondition creates a NRQL condition of the given type and returns
+// its ID.
+func createNrqlCondition(client *newrelic.NewRelic, accountID int, policyID string, conditionType string, input *nrqlConditionInput) (string, error) {
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"policyId":  policyID,
+		"condition": input,
+	}
+
+	return mutateNrqlCondition(client, createNrqlConditionMutation, "Create", conditionType, vars)
+}
+
+// updateNrqlCondition updates a NRQL condition of the given type.
+func updateNrqlCondition(client *newrelic.NewRelic, accountID int, conditionID string, conditionType string, input *nrqlConditionInput) error {
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"id":        conditionID,
+		"condition": input,
+	}
+
+	_, err := mutateNrqlCondition(client, updateNrqlConditionMutation, "Update", conditionType, vars)
+
+	return err
+}
+
+// nrqlConditionTypeName returns the name of a NRQL condition type as used in
+// the names of its mutations, e.g. Static for STATIC.
+func nrqlConditionTypeName(conditionType string) string {
+	if conditionType == "" {
+		return ""
+	}
+
+	return strings.ToUpper(conditionType[:1]) + strings.ToLower(conditionType[1:])
+}
+
+func mutateNrqlCondition(client *newrelic.NewRelic, mutation string, operation string, conditionType string, vars map[string]interface{}) (string, error) {
+	typeName := nrqlConditionTypeName(conditionType)
+	field := fmt.Sprintf("alertsNrqlCondition%s%s", typeName, operation)
+
+	resp := map[string]struct {
+		ID string `json:"id"`
+	}{}
+
+	if err := client.NerdGraph.QueryWithResponse(fmt.Sprintf(mutation, typeName), vars, &resp); err != nil {
+		return "", err
+	}
+
+	result, ok := resp[field]
+	if !ok || result.ID == "" {
+		return "", fmt.Errorf("no NRQL alert condition returned by %s", field)
+	}
+
+	return result.ID, nil
+}
+
+// getNrqlCondition fetches a NRQL condition along with its full signal.
+func getNrqlCondition(client *newrelic.NewRelic, accountID int, conditionID string) (*nrqlAlertCondition, error) {
+	condition, err := client.Alerts.GetNrqlConditionQuery(accountID, conditionID)
+	if err != nil {
+		return nil, err
+	}
+
+	signal, err := getNrqlConditionSignal(client, accountID, conditionID)
+	if err != nil {
+		return nil, err
+	}
+
+	c := newNrqlAlertCondition(condition)
+	c.Signal = signal
+
+	return c, nil
+}
+
+func getNrqlConditionSignal(client *newrelic.NewRelic, accountID int, conditionID string) (*nrqlConditionSignal, error) {
+	var resp struct {
+		Actor struct {
+			Account struct {
+				Alerts struct {
+					NrqlCondition struct {
+						Signal *nrqlConditionSignal `json:"signal"`
+					} `json:"nrqlCondition"`
+				} `json:"alerts"`
+			} `json:"account"`
+		} `json:"actor"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"id":        conditionID,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(getNrqlConditionSignalQuery, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Actor.Account.Alerts.NrqlCondition.Signal, nil
+}
@@ -0,0 +1,397 @@
+package newrelic
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/dashboards"
+	"github.com/newrelic/newrelic-client-go/pkg/entities"
+)
+
+// newrelic-client-go does not model the variables of a dashboard nor read the
+// raw configuration of its widgets, which holds the configuration of the
+// visualizations without a typed configuration. Dashboards are written with
+// the mutations below, and these attributes are read back separately.
+
+// dashboardWidgetRawConfiguration is the raw configuration of the widgets
+// without a typed configuration.
+type dashboardWidgetRawConfiguration struct {
+	NRQLQueries []entities.DashboardWidgetNRQLQuery `json:"nrqlQueries"`
+	Limit       float64                             `json:"limit,omitempty"`
+}
+
+// dashboardVariable is a template variable of a dashboard, as written and read.
+type dashboardVariable struct {
+	DefaultValues       []dashboardVariableDefaultItem `json:"defaultValues,omitempty"`
+	IsMultiSelection    bool                           `json:"isMultiSelection"`
+	Items               []dashboardVariableEnumItem    `json:"items,omitempty"`
+	Name                string                         `json:"name"`
+	NRQLQuery           *dashboardVariableNRQLQuery    `json:"nrqlQuery,omitempty"`
+	ReplacementStrategy string                         `json:"replacementStrategy,omitempty"`
+	Title               string                         `json:"title,omitempty"`
+	Type                string                         `json:"type"`
+}
+
+type dashboardVariableDefaultItem struct {
+	Value struct {
+		String string `json:"string"`
+	} `json:"value"`
+}
+
+type dashboardVariableEnumItem struct {
+	Title string `json:"title,omitempty"`
+	Value string `json:"value"`
+}
+
+type dashboardVariableNRQLQuery struct {
+	AccountIDs []int  `json:"accountIds"`
+	Query      string `json:"query"`
+}
+
+// dashboardInput is the input of the dashboard create and update mutations.
+type dashboardInput struct {
+	dashboards.DashboardInput
+
+	Variables []dashboardVariable `json:"variables"`
+
+	// filterCurrentDashboard holds the indexes of the widgets that filter the
+	// current dashboard, by page.
+	filterCurrentDashboard [][]int
+}
+
+// dashboardEntityResult is a dashboard as returned by the dashboard update
+// mutation.
+type dashboardEntityResult struct {
+	dashboards.DashboardEntityResult
+
+	Variables []dashboardVariable `json:"variables"`
+}
+
+// dashboardEntityDetails holds the attributes of a dashboard that
+// newrelic-client-go does not read.
+type dashboardEntityDetails struct {
+	Pages []struct {
+		Widgets []struct {
+			ID               string                                   `json:"id"`
+			RawConfiguration entities.DashboardWidgetRawConfiguration `json:"rawConfiguration"`
+		} `json:"widgets"`
+	} `json:"pages"`
+	Variables []dashboardVariable `json:"variables"`
+}
+
+// dashboardError is an error returned by the dashboard mutations.
+type dashboardError struct {
+	Description string `json:"description"`
+	Type        string `json:"type"`
+}
+
+const (
+	graphqlDashboardVariableFields = `
+		variables {
+			defaultValues { value { string } }
+			isMultiSelection
+			items { title value }
+			name
+			nrqlQuery { accountIds query }
+			replacementStrategy
+			title
+			type
+		}`
+
+	graphqlDashboardEntityResultFields = `
+		accountId
+		description
+		guid
+		name
+		permissions
+		pages {
+			description
+			guid
+			name
+			widgets {
+				id
+				title
+				layout { column height row width }
+				visualization { id }
+				configuration {
+					area { nrqlQueries { accountId query } }
+					bar { nrqlQueries { accountId query } }
+					billboard { nrqlQueries { accountId query } thresholds { alertSeverity value } }
+					line { nrqlQueries { accountId query } }
+					markdown { text }
+					pie { nrqlQueries { accountId query } }
+					table { nrqlQueries { accountId query } }
+				}
+				rawConfiguration
+				linkedEntities { __typename guid }
+			}
+		}` +
+		graphqlDashboardVariableFields
+
+	getDashboardEntityDetailsQuery = `
+		query($guid: EntityGuid!) {
+			actor {
+				entity(guid: $guid) {
+					... on DashboardEntity {
+						pages {
+							widgets {
+								id
+								rawConfiguration
+							}
+						}` +
+		graphqlDashboardVariableFields +
+		`} } } }`
+
+	searchDashboardsQuery = `
+		query($query: String!, $cursor: String) {
+			actor {
+				entitySearch(query: $query) {
+					results(cursor: $cursor) {
+						nextCursor
+						entities {
+							guid
+							... on DashboardEntityOutline {
+								dashboardParentGuid
+							}
+						}
+					}
+				}
+			}
+		}`
+
+	createDashboardMutation = `
+		mutation($accountId: Int!, $dashboard: DashboardInput!) {
+			dashboardCreate(accountId: $accountId, dashboard: $dashboard) {
+				entityResult {
+					guid
+				}
+				errors {
+					description
+					type
+				}
+			}
+		}`
+
+	updateDashboardMutation = `
+		mutation($guid: EntityGuid!, $dashboard: DashboardInput!) {
+			dashboardUpdate(guid: $guid, dashboard: $dashboard) {
+				entityResult {` +
+		graphqlDashboardEntityResultFields +
+		`}
+				errors {
+					description
+					type
+				}
+			}
+		}`
+)
+
+// createDashboard creates a dashboard and returns its GUID.
+func createDashboard(client *newrelic.NewRelic, accountID int, input *dashboardInput) (entities.EntityGUID, error) {
+	var resp struct {
+		DashboardCreate struct {
+			EntityResult struct {
+				GUID entities.EntityGUID `json:"guid"`
+			} `json:"entityResult"`
+			Errors []dashboardError `json:"errors"`
+		} `json:"dashboardCreate"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"dashboard": input,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(createDashboardMutation, vars, &resp); err != nil {
+		return "", err
+	}
+
+	if err := dashboardErrors(resp.DashboardCreate.Errors); err != nil {
+		return "", err
+	}
+
+	return resp.DashboardCreate.EntityResult.GUID, nil
+}
+
+// updateDashboard updates a dashboard and returns it as updated, since a
+// dashboard read right after an update may not reflect it yet.
+func updateDashboard(client *newrelic.NewRelic, guid entities.EntityGUID, input *dashboardInput) (*dashboardEntityResult, error) {
+	var resp struct {
+		DashboardUpdate struct {
+			EntityResult dashboardEntityResult `json:"entityResult"`
+			Errors       []dashboardError      `json:"errors"`
+		} `json:"dashboardUpdate"`
+	}
+
+	vars := map[string]interface{}{
+		"guid":      guid,
+		"dashboard": input,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(updateDashboardMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	if err := dashboardErrors(resp.DashboardUpdate.Errors); err != nil {
+		return nil, err
+	}
+
+	return &resp.DashboardUpdate.EntityResult, nil
+}
+
+// getDashboard reads a dashboard with newrelic-client-go, along with the
+// attributes it does not read.
+func getDashboard(client *newrelic.NewRelic, guid entities.EntityGUID) (*entities.DashboardEntity, []dashboardVariable, error) {
+	dashboard, err := client.Dashboards.GetDashboardEntity(guid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	details, err := getDashboardEntityDetails(client, guid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	details.setRawConfigurations(dashboard)
+
+	return dashboard, details.Variables, nil
+}
+
+// getDashboardEntityDetails reads the attributes of a dashboard that
+// newrelic-client-go does not read.
+func getDashboardEntityDetails(client *newrelic.NewRelic, guid entities.EntityGUID) (*dashboardEntityDetails, error) {
+	var resp struct {
+		Actor struct {
+			Entity dashboardEntityDetails `json:"entity"`
+		} `json:"actor"`
+	}
+
+	vars := map[string]interface{}{
+		"guid": guid,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(getDashboardEntityDetailsQuery, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Actor.Entity, nil
+}
+
+// searchDashboards returns the GUIDs of the dashboards of an account, following
+// the cursor of the entity search. Dashboard pages are entities too, they are
+// left out.
+func searchDashboards(client *newrelic.NewRelic, accountID int) ([]entities.EntityGUID, error) {
+	var guids []entities.EntityGUID
+	var cursor *string
+
+	for {
+		var resp struct {
+			Actor struct {
+				EntitySearch struct {
+					Results struct {
+						NextCursor *string `json:"nextCursor"`
+						Entities   []struct {
+							GUID                entities.EntityGUID `json:"guid"`
+							DashboardParentGUID entities.EntityGUID `json:"dashboardParentGuid"`
+						} `json:"entities"`
+					} `json:"results"`
+				} `json:"entitySearch"`
+			} `json:"actor"`
+		}
+
+		vars := map[string]interface{}{
+			"query":  fmt.Sprintf("accountId = %d AND type = 'DASHBOARD'", accountID),
+			"cursor": cursor,
+		}
+
+		if err := client.NerdGraph.QueryWithResponse(searchDashboardsQuery, vars, &resp); err != nil {
+			return nil, err
+		}
+
+		results := resp.Actor.EntitySearch.Results
+		for _, entity := range results.Entities {
+			if entity.DashboardParentGUID == "" {
+				guids = append(guids, entity.GUID)
+			}
+		}
+
+		if results.NextCursor == nil || *results.NextCursor == "" {
+			return guids, nil
+		}
+		cursor = results.NextCursor
+	}
+}
+
+// setRawConfigurations sets the raw configuration of the widgets of a dashboard
+// read with newrelic-client-go.
+func (details *dashboardEntityDetails) setRawConfigurations(dashboard *entities.DashboardEntity) {
+	rawConfigurations := map[string]entities.DashboardWidgetRawConfiguration{}
+
+	for _, page := range details.Pages {
+		for _, widget := range page.Widgets {
+			rawConfigurations[widget.ID] = widget.RawConfiguration
+		}
+	}
+
+	for i := range dashboard.Pages {
+		for j := range dashboard.Pages[i].Widgets {
+			widget := &dashboard.Pages[i].Widgets[j]
+			widget.RawConfiguration = rawConfigurations[widget.ID]
+		}
+	}
+}
+
+// linkFilterCurrentDashboard links the widgets that filter the current
+// dashboard to their page. Pages only get a GUID once saved, so it returns
+// whether widgets of new pages are left to link.
+func (input *dashboardInput) linkFilterCurrentDashboard() bool {
+	pending := false
+
+	for i, widgets := range input.filterCurrentDashboard {
+		page := &input.Pages[i]
+
+		if page.GUID == "" {
+			pending = pending || len(widgets) > 0
+			continue
+		}
+
+		for _, j := range widgets {
+			widget := &page.Widgets[j]
+
+			linked := false
+			for _, guid := range widget.LinkedEntityGUIDs {
+				linked = linked || guid == page.GUID
+			}
+
+			if !linked {
+				widget.LinkedEntityGUIDs = append(widget.LinkedEntityGUIDs, page.GUID)
+			}
+		}
+	}
+
+	return pending
+}
+
+// setPageGUIDs sets the GUIDs of the pages of the saved dashboard, which are
+// in the same order as the input.
+func (input *dashboardInput) setPageGUIDs(pages []entities.DashboardPage) {
+	for i := range input.Pages {
+		if i < len(pages) {
+			input.Pages[i].GUID = pages[i].GUID
+		}
+	}
+}
+
+func dashboardErrors(errs []dashboardError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	descriptions := make([]string, len(errs))
+	for i, e := range errs {
+		descriptions[i] = fmt.Sprintf("%s: %s", e.Type, e.Description)
+	}
+
+	return fmt.Errorf("error saving dashboard: %s", strings.Join(descriptions, ", "))
+}
@@ -0,0 +1,356 @@
+package newrelic
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	nrErrors "github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+// newrelic-client-go does not cover workflows yet, so they are managed with the
+// NerdGraph queries and mutations below.
+
+var workflowMutingRulesHandlings = []string{
+	"DONT_NOTIFY_FULLY_MUTED_ISSUES",
+	"DONT_NOTIFY_FULLY_OR_PARTIALLY_MUTED_ISSUES",
+	"NOTIFY_ALL_ISSUES",
+}
+
+var workflowFilterTypes = []string{
+	"FILTER",
+	"VIEW",
+}
+
+var workflowPredicateOperators = []string{
+	"CONTAINS",
+	"DOES_NOT_CONTAIN",
+	"DOES_NOT_EQUAL",
+	"DOES_NOT_EXACTLY_MATCH",
+	"ENDS_WITH",
+	"EQUAL",
+	"EXACTLY_MATCHES",
+	"GREATER_OR_EQUAL",
+	"GREATER_THAN",
+	"IS",
+	"IS_NOT",
+	"LESS_OR_EQUAL",
+	"LESS_THAN",
+	"STARTS_WITH",
+}
+
+var workflowNotificationTriggers = []string{
+	"ACKNOWLEDGED",
+	"ACTIVATED",
+	"CLOSED",
+	"OTHER_UPDATES",
+	"PRIORITY_CHANGED",
+}
+
+type workflowPredicate struct {
+	Attribute string   `json:"attribute"`
+	Operator  string   `json:"operator"`
+	Values    []string `json:"values"`
+}
+
+type workflowFilterInput struct {
+	Name       string              `json:"name"`
+	Type       string              `json:"type"`
+	Predicates []workflowPredicate `json:"predicates"`
+}
+
+// workflowUpdatedFilterInput replaces the issues filter of a workflow.
+type workflowUpdatedFilterInput struct {
+	ID          string              `json:"id"`
+	FilterInput workflowFilterInput `json:"filterInput"`
+}
+
+type workflowNrqlConfiguration struct {
+	Query string `json:"query"`
+}
+
+// workflowNrqlEnrichmentInput is a NRQL enrichment, which is updated when it
+// has an ID and created otherwise.
+type workflowNrqlEnrichmentInput struct {
+	ID            string                      `json:"id,omitempty"`
+	Name          string                      `json:"name"`
+	Configuration []workflowNrqlConfiguration `json:"configuration"`
+}
+
+type workflowEnrichmentsInput struct {
+	Nrql []workflowNrqlEnrichmentInput `json:"nrql"`
+}
+
+type workflowDestinationConfigurationInput struct {
+	ChannelID            string   `json:"channelId"`
+	NotificationTriggers []string `json:"notificationTriggers,omitempty"`
+}
+
+// workflowInput holds what the workflow create and update inputs have in
+// common.
+type workflowInput struct {
+	Name                      string                                  `json:"name"`
+	WorkflowEnabled           bool                                    `json:"workflowEnabled"`
+	DestinationsEnabled       bool                                    `json:"destinationsEnabled"`
+	EnrichmentsEnabled        bool                                    `json:"enrichmentsEnabled"`
+	MutingRulesHandling       string                                  `json:"mutingRulesHandling"`
+	Enrichments               *workflowEnrichmentsInput               `json:"enrichments,omitempty"`
+	DestinationConfigurations []workflowDestinationConfigurationInput `json:"destinationConfigurations"`
+}
+
+type workflowCreateInput struct {
+	workflowInput
+
+	IssuesFilter *workflowFilterInput `json:"issuesFilter"`
+}
+
+type workflowUpdateInput struct {
+	workflowInput
+
+	ID           string                      `json:"id"`
+	IssuesFilter *workflowUpdatedFilterInput `json:"issuesFilter"`
+}
+
+type workflowFilter struct {
+	ID         string              `json:"id"`
+	Name       string              `json:"name"`
+	Type       string              `json:"type"`
+	Predicates []workflowPredicate `json:"predicates"`
+}
+
+type workflowEnrichment struct {
+	ID             string                      `json:"id"`
+	Name           string                      `json:"name"`
+	Type           string                      `json:"type"`
+	Configurations []workflowNrqlConfiguration `json:"configurations"`
+}
+
+type workflowDestinationConfiguration struct {
+	ChannelID            string   `json:"channelId"`
+	Name                 string   `json:"name"`
+	Type                 string   `json:"type"`
+	NotificationTriggers []string `json:"notificationTriggers"`
+}
+
+type workflow struct {
+	ID                        string                             `json:"id"`
+	Name                      string                             `json:"name"`
+	WorkflowEnabled           bool                               `json:"workflowEnabled"`
+	DestinationsEnabled       bool                               `json:"destinationsEnabled"`
+	EnrichmentsEnabled        bool                               `json:"enrichmentsEnabled"`
+	MutingRulesHandling       string                             `json:"mutingRulesHandling"`
+	IssuesFilter              *workflowFilter                    `json:"issuesFilter"`
+	Enrichments               []workflowEnrichment               `json:"enrichments"`
+	DestinationConfigurations []workflowDestinationConfiguration `json:"destinationConfigurations"`
+}
+
+type workflowError struct {
+	Description string `json:"description"`
+	Type        string `json:"type"`
+}
+
+// workflowErrors turns the errors returned in the payload of the workflow
+// mutations into a single error.
+func workflowErrors(errs []workflowError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(errs))
+	for _, e := range errs {
+		messages = append(messages, fmt.Sprintf("%s: %s", e.Type, e.Description))
+	}
+
+	return fmt.Errorf("workflow error: %s", strings.Join(messages, ", "))
+}
+
+const (
+	graphqlWorkflowFields = `
+		id
+		name
+		workflowEnabled
+		destinationsEnabled
+		enrichmentsEnabled
+		mutingRulesHandling
+		issuesFilter {
+			id
+			name
+			type
+			predicates {
+				attribute
+				operator
+				values
+			}
+		}
+		enrichments {
+			id
+			name
+			type
+			configurations {
+				... on AiWorkflowsNrqlConfiguration {
+					query
+				}
+			}
+		}
+		destinationConfigurations {
+			channelId
+			name
+			type
+			notificationTriggers
+		}`
+
+	graphqlWorkflowErrorFields = `
+		errors {
+			description
+			type
+		}`
+
+	createWorkflowMutation = `
+		mutation($accountId: Int!, $workflow: AiWorkflowsCreateWorkflowInput!) {
+			aiWorkflowsCreateWorkflow(accountId: $accountId, createWorkflowData: $workflow) {
+				workflow {` +
+		graphqlWorkflowFields + `
+				}` +
+		graphqlWorkflowErrorFields + `
+			}
+		}`
+
+	updateWorkflowMutation = `
+		mutation($accountId: Int!, $workflow: AiWorkflowsUpdateWorkflowInput!) {
+			aiWorkflowsUpdateWorkflow(accountId: $accountId, updateWorkflowData: $workflow) {
+				workflow {` +
+		graphqlWorkflowFields + `
+				}` +
+		graphqlWorkflowErrorFields + `
+			}
+		}`
+
+	deleteWorkflowMutation = `
+		mutation($accountId: Int!, $id: ID!) {
+			aiWorkflowsDeleteWorkflow(accountId: $accountId, id: $id, deleteChannels: false) {
+				id` +
+		graphqlWorkflowErrorFields + `
+			}
+		}`
+
+	getWorkflowQuery = `
+		query($accountId: Int!, $id: ID!) {
+			actor {
+				account(id: $accountId) {
+					aiWorkflows {
+						workflows(filters: {id: $id}) {
+							entities {` +
+		graphqlWorkflowFields + `
+							}
+						}
+					}
+				}
+			}
+		}`
+)
+
+type workflowResponse struct {
+	Workflow *workflow       `json:"workflow"`
+	Errors   []workflowError `json:"errors"`
+}
+
+func createWorkflow(client *newrelic.NewRelic, accountID int, input *workflowCreateInput) (*workflow, error) {
+	var resp struct {
+		Result workflowResponse `json:"aiWorkflowsCreateWorkflow"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"workflow":  input,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(createWorkflowMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	return workflowResult(resp.Result)
+}
+
+func updateWorkflow(client *newrelic.NewRelic, accountID int, input *workflowUpdateInput) (*workflow, error) {
+	var resp struct {
+		Result workflowResponse `json:"aiWorkflowsUpdateWorkflow"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"workflow":  input,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(updateWorkflowMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	return workflowResult(resp.Result)
+}
+
+func workflowResult(result workflowResponse) (*workflow, error) {
+	if err := workflowErrors(result.Errors); err != nil {
+		return nil, err
+	}
+
+	if result.Workflow == nil {
+		return nil, fmt.Errorf("no workflow returned")
+	}
+
+	return result.Workflow, nil
+}
+
+// deleteWorkflow deletes a workflow, leaving its channels to be deleted along
+// with their own resources.
+func deleteWorkflow(client *newrelic.NewRelic, accountID int, workflowID string) error {
+	var resp struct {
+		Result struct {
+			ID     string          `json:"id"`
+			Errors []workflowError `json:"errors"`
+		} `json:"aiWorkflowsDeleteWorkflow"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"id":        workflowID,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(deleteWorkflowMutation, vars, &resp); err != nil {
+		return err
+	}
+
+	return workflowErrors(resp.Result.Errors)
+}
+
+// getWorkflow returns a *errors.NotFound error when there is no workflow with
+// the given ID.
+func getWorkflow(client *newrelic.NewRelic, accountID int, workflowID string) (*workflow, error) {
+	var resp struct {
+		Actor struct {
+			Account struct {
+				AIWorkflows struct {
+					Workflows struct {
+						Entities []*workflow `json:"entities"`
+					} `json:"workflows"`
+				} `json:"aiWorkflows"`
+			} `json:"account"`
+		} `json:"actor"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"id":        workflowID,
+	}
+
+	if err := client.NerdGraph.QueryWithResponse(getWorkflowQuery, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	for _, w := range resp.Actor.Account.AIWorkflows.Workflows.Entities {
+		if w.ID == workflowID {
+			return w, nil
+		}
+	}
+
+	return nil, nrErrors.NewNotFoundf("workflow %s not found", workflowID)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
@@ -99,6 +100,12 @@ func Provider() terraform.ResourceProvider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("NEW_RELIC_INSIGHTS_INSERT_URL", insightsInsertURL),
 			},
+			"insights_query_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NEW_RELIC_INSIGHTS_QUERY_KEY", nil),
+				Sensitive:   true,
+			},
 			"insights_query_url": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -114,15 +121,86 @@ func Provider() terraform.ResourceProvider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("NEW_RELIC_API_CACERT", ""),
 			},
+			"default_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Tags applied to the entities of every resource that supports them.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tags": {
+							Type:        schema.TypeMap,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The tag keys and values to apply.",
+						},
+					},
+				},
+			},
+			"client_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NEW_RELIC_CLIENT_CERT", ""),
+				Description: "A path to, or the contents of, a PEM-encoded client certificate presented to the API and proxy.",
+			},
+			"client_key_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NEW_RELIC_CLIENT_KEY", ""),
+				Description: "A path to, or the contents of, the PEM-encoded private key for client_cert_file.",
+			},
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NEW_RELIC_PROXY_URL", nil),
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+				Description:  "The URL of an HTTP(S) proxy API requests are sent through. Proxy credentials can be included in the URL.",
+			},
+			"no_proxy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NEW_RELIC_NO_PROXY", ""),
+				Description: "A comma-separated list of hosts that bypass the proxy.",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NEW_RELIC_MAX_RETRIES", defaultMaxRetries),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum number of times a rate limited or failed API request is retried.",
+			},
+			"retry_idempotent_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NEW_RELIC_RETRY_IDEMPOTENT_ONLY", false),
+				Description: "Whether to only retry the requests that are safe to send twice after a server error.",
+			},
+			"retry_wait_min": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NEW_RELIC_RETRY_WAIT_MIN", int(defaultRetryWaitMin.Seconds())),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The minimum time in seconds to wait before retrying an API request.",
+			},
+			"retry_wait_max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NEW_RELIC_RETRY_WAIT_MAX", int(defaultRetryWaitMax.Seconds())),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The maximum time in seconds to wait before retrying an API request.",
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
 			"newrelic_account":                      dataSourceNewRelicAccount(),
 			"newrelic_alert_channel":                dataSourceNewRelicAlertChannel(),
+			"newrelic_alert_conditions":             dataSourceNewRelicAlertConditions(),
 			"newrelic_alert_policy":                 dataSourceNewRelicAlertPolicy(),
 			"newrelic_application":                  dataSourceNewRelicApplication(),
 			"newrelic_entity":                       dataSourceNewRelicEntity(),
 			"newrelic_key_transaction":              dataSourceNewRelicKeyTransaction(),
+			"newrelic_nrql_query":                   dataSourceNewRelicNrqlQuery(),
+			"newrelic_nrql_equivalent":              dataSourceNewRelicNrqlEquivalent(),
 			"newrelic_plugin":                       dataSourceNewRelicPlugin(),
 			"newrelic_plugin_component":             dataSourceNewRelicPluginComponent(),
 			"newrelic_synthetics_monitor":           dataSourceNewRelicSyntheticsMonitor(),
@@ -135,6 +213,7 @@ func Provider() terraform.ResourceProvider {
 			"newrelic_alert_condition":                          resourceNewRelicAlertCondition(),
 			"newrelic_alert_muting_rule":                        resourceNewRelicAlertMutingRule(),
 			"newrelic_alert_policy":                             resourceNewRelicAlertPolicy(),
+			"newrelic_alert_policy_bundle":                      resourceNewRelicAlertPolicyBundle(),
 			"newrelic_alert_policy_channel":                     resourceNewRelicAlertPolicyChannel(),
 			"newrelic_api_access_key":                           resourceNewRelicAPIAccessKey(),
 			"newrelic_application_settings":                     resourceNewRelicApplicationSettings(),
@@ -143,14 +222,18 @@ func Provider() terraform.ResourceProvider {
 			"newrelic_events_to_metrics_rule":                   resourceNewRelicEventsToMetricsRule(),
 			"newrelic_infra_alert_condition":                    resourceNewRelicInfraAlertCondition(),
 			"newrelic_insights_event":                           resourceNewRelicInsightsEvent(),
+			"newrelic_notification_channel":                     resourceNewRelicNotificationChannel(),
+			"newrelic_notification_destination":                 resourceNewRelicNotificationDestination(),
 			"newrelic_nrql_alert_condition":                     resourceNewRelicNrqlAlertCondition(),
 			"newrelic_one_dashboard":                            resourceNewRelicOneDashboard(),
+			"newrelic_one_dashboard_json":                       resourceNewRelicOneDashboardJSON(),
 			"newrelic_plugins_alert_condition":                  resourceNewRelicPluginsAlertCondition(),
 			"newrelic_synthetics_alert_condition":               resourceNewRelicSyntheticsAlertCondition(),
 			"newrelic_synthetics_monitor":                       resourceNewRelicSyntheticsMonitor(),
 			"newrelic_synthetics_monitor_script":                resourceNewRelicSyntheticsMonitorScript(),
 			"newrelic_synthetics_multilocation_alert_condition": resourceNewRelicSyntheticsMultiLocationAlertCondition(),
 			"newrelic_synthetics_secure_credential":             resourceNewRelicSyntheticsSecureCredential(),
+			"newrelic_workflow":                                 resourceNewRelicWorkflow(),
 			"newrelic_workload":                                 resourceNewRelicWorkload(),
 		},
 	}
@@ -187,7 +270,20 @@ func providerConfigure(data *schema.ResourceData, terraformVersion string) (inte
 		userAgent:            userAgent,
 		InsecureSkipVerify:   data.Get("insecure_skip_verify").(bool),
 		CACertFile:           data.Get("cacert_file").(string),
+		ClientCertFile:       data.Get("client_cert_file").(string),
+		ClientKeyFile:        data.Get("client_key_file").(string),
+		ProxyURL:             data.Get("proxy_url").(string),
+		NoProxy:              data.Get("no_proxy").(string),
+		MaxRetries:           data.Get("max_retries").(int),
+		RetryIdempotentOnly:  data.Get("retry_idempotent_only").(bool),
+		RetryWaitMin:         time.Duration(data.Get("retry_wait_min").(int)) * time.Second,
+		RetryWaitMax:         time.Duration(data.Get("retry_wait_max").(int)) * time.Second,
 	}
+
+	if cfg.RetryWaitMin > cfg.RetryWaitMax {
+		return nil, fmt.Errorf("retry_wait_min (%s) must not be greater than retry_wait_max (%s)", cfg.RetryWaitMin, cfg.RetryWaitMax)
+	}
+
 	log.Println("[INFO] Initializing newrelic-client-go")
 
 	client, err := cfg.Client()
@@ -196,18 +292,43 @@ func providerConfigure(data *schema.ResourceData, terraformVersion string) (inte
 	}
 
 	insightsInsertConfig := Config{
-		InsightsAccountID: strconv.Itoa(accountID),
-		InsightsInsertKey: data.Get("insights_insert_key").(string),
-		InsightsInsertURL: data.Get("insights_insert_url").(string),
+		InsightsAccountID:  strconv.Itoa(accountID),
+		InsightsInsertKey:  data.Get("insights_insert_key").(string),
+		InsightsInsertURL:  data.Get("insights_insert_url").(string),
+		CACertFile:         cfg.CACertFile,
+		ClientCertFile:     cfg.ClientCertFile,
+		ClientKeyFile:      cfg.ClientKeyFile,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+		NoProxy:            cfg.NoProxy,
+		ProxyURL:           cfg.ProxyURL,
 	}
 	clientInsightsInsert, err := insightsInsertConfig.ClientInsightsInsert()
 	if err != nil {
 		return nil, fmt.Errorf("error initializing New Relic Insights insert client: %w", err)
 	}
 
+	insightsQueryConfig := Config{
+		InsightsAccountID:  strconv.Itoa(accountID),
+		InsightsQueryKey:   data.Get("insights_query_key").(string),
+		InsightsQueryURL:   data.Get("insights_query_url").(string),
+		CACertFile:         cfg.CACertFile,
+		ClientCertFile:     cfg.ClientCertFile,
+		ClientKeyFile:      cfg.ClientKeyFile,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+		NoProxy:            cfg.NoProxy,
+		ProxyURL:           cfg.ProxyURL,
+	}
+	clientInsightsQuery, err := insightsQueryConfig.ClientInsightsQuery()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing New Relic Insights query client: %w", err)
+	}
+
 	providerConfig := ProviderConfig{
 		NewClient:            client,
+		DefaultTags:          expandDefaultTags(data.Get("default_tags").([]interface{})),
 		InsightsInsertClient: clientInsightsInsert,
+		InsightsQueryClient:  clientInsightsQuery,
+		InsightsQueryKey:     insightsQueryConfig.InsightsQueryKey,
 		PersonalAPIKey:       personalAPIKey,
 		AccountID:            accountID,
 	}
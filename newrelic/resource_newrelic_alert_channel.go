@@ -3,11 +3,16 @@ package newrelic
 import (
 	"fmt"
 	"log"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
 	"github.com/newrelic/newrelic-client-go/pkg/errors"
 )
 
@@ -46,25 +51,78 @@ var alertChannelTypes = map[string][]string{
 	},
 }
 
+// alertChannelTypedConfigSchemas are the configuration blocks of the channel
+// types the REST API has no type for. Channels of these types are created as
+// webhooks, configured by a block named after the type instead of config.
+func alertChannelTypedConfigSchemas() map[string]map[string]*schema.Schema {
+	return map[string]map[string]*schema.Schema{
+		"teams": {
+			"url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.IsURLWithHTTPS,
+				Description:  "The URL of the incoming webhook of the Microsoft Teams channel.",
+			},
+		},
+		"xmatters": {
+			"url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.IsURLWithHTTPS,
+				Description:  "The URL of the inbound integration of the xMatters workflow.",
+			},
+			"username": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"xmatters.0.password"},
+				Description:  "The user authenticating with the inbound integration, if it uses basic authentication.",
+			},
+			"password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"xmatters.0.username"},
+				Description:  "The password of the user.",
+			},
+			"recipients": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The xMatters users or groups to notify. Multiple values are comma separated.",
+			},
+		},
+	}
+}
+
 func resourceNewRelicAlertChannel() *schema.Resource {
 	validAlertChannelTypes := make([]string, 0, len(alertChannelTypes))
 	for k := range alertChannelTypes {
 		validAlertChannelTypes = append(validAlertChannelTypes, k)
 	}
+	for k := range alertChannelWebhookTypes {
+		validAlertChannelTypes = append(validAlertChannelTypes, k)
+	}
 
-	return &schema.Resource{
+	r := &schema.Resource{
 		Create: resourceNewRelicAlertChannelCreate,
 		Read:   resourceNewRelicAlertChannelRead,
-		// Update: Not currently supported in API
+		Update: resourceNewRelicAlertChannelUpdate,
 		Delete: resourceNewRelicAlertChannelDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceImportStateWithAlertChannelType,
 		},
+		CustomizeDiff: customdiff.All(
+			validateAlertChannelTypedConfig,
+			validateAlertChannelPayload,
+			// NerdGraph cannot update user channels.
+			customdiff.ForceNewIf("name", isAlertChannelOfTypeUser),
+			customdiff.ForceNewIf("config", isAlertChannelOfTypeUser),
+		),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 				Description: "(Required) The name of the channel.",
 			},
 			"type": {
@@ -77,7 +135,6 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 			"config": {
 				Type:        schema.TypeList,
 				Optional:    true,
-				ForceNew:    true,
 				MaxItems:    1,
 				Description: "The configuration block for the alert channel.",
 				Elem: &schema.Resource{
@@ -86,14 +143,12 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 							Type:        schema.TypeString,
 							Optional:    true,
 							Sensitive:   true,
-							ForceNew:    true,
 							Description: "The API key for integrating with OpsGenie.",
 						},
 						"auth_password": {
 							Type:        schema.TypeString,
 							Optional:    true,
 							Sensitive:   true,
-							ForceNew:    true,
 							Description: "Specifies an authentication password for use with a channel. Supported by the webhook channel type.",
 						},
 						"auth_type": {
@@ -101,26 +156,22 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 							Optional:     true,
 							Sensitive:    true,
 							ValidateFunc: validation.StringInSlice([]string{"BASIC"}, false),
-							ForceNew:     true,
 							Description:  "Specifies an authentication method for use with a channel. Supported by the webhook channel type. Only HTTP basic authentication is currently supported via the value BASIC.",
 						},
 						"auth_username": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							ForceNew:    true,
 							Description: "Specifies an authentication username for use with a channel. Supported by the webhook channel type.",
 						},
 						"base_url": {
 							Type:        schema.TypeString,
 							Optional:    true,
 							Sensitive:   true,
-							ForceNew:    true,
 							Description: "The base URL of the webhook destination.",
 						},
 						"channel": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							ForceNew:    true,
 							Description: "The Slack channel to send notifications to.",
 						},
 						"headers": {
@@ -128,7 +179,6 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 							Elem:          &schema.Schema{Type: schema.TypeString},
 							Optional:      true,
 							Sensitive:     true,
-							ForceNew:      true,
 							ConflictsWith: []string{"config.0.headers_string"},
 							Description:   "A map of key/value pairs that represents extra HTTP headers to be sent along with the webhook payload.",
 						},
@@ -136,7 +186,7 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 							Type:          schema.TypeString,
 							Optional:      true,
 							Sensitive:     true,
-							ForceNew:      true,
+							ValidateFunc:  validateJSONObject,
 							ConflictsWith: []string{"config.0.headers"},
 							Description:   "Use instead of headers if the desired payload is more complex than a list of key/value pairs (e.g. a set of headers that makes use of nested objects). The value provided should be a valid JSON string with escaped double quotes. Conflicts with headers.",
 							// Suppress the diff shown if the differences are solely due to whitespace
@@ -148,13 +198,11 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 							Type:        schema.TypeString,
 							Optional:    true,
 							Sensitive:   true,
-							ForceNew:    true,
 							Description: "The key for integrating with VictorOps.",
 						},
 						"include_json_attachment": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							ForceNew:    true,
 							Description: "0 or 1. Flag for whether or not to attach a JSON document containing information about the associated alert to the email that is sent to recipients.",
 						},
 						"payload": {
@@ -162,16 +210,27 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 							Elem:          &schema.Schema{Type: schema.TypeString},
 							Sensitive:     true,
 							Optional:      true,
-							ForceNew:      true,
-							ConflictsWith: []string{"config.0.payload_string"},
+							ConflictsWith: []string{"config.0.payload_string", "config.0.payload_json"},
 							Description:   "A map of key/value pairs that represents the webhook payload. Must provide payload_type if setting this argument.",
 						},
+						"payload_json": {
+							Type:          schema.TypeString,
+							Optional:      true,
+							Sensitive:     true,
+							ValidateFunc:  validateJSONObject,
+							ConflictsWith: []string{"config.0.payload", "config.0.payload_string"},
+							Description:   "The webhook payload as a JSON object. Unlike payload_string, differences in formatting and key order are not shown as changes. Conflicts with payload and payload_string.",
+							StateFunc: func(v interface{}) string {
+								payload, _ := structure.NormalizeJsonString(v)
+								return payload
+							},
+						},
 						"payload_string": {
 							Type:          schema.TypeString,
 							Optional:      true,
 							Sensitive:     true,
-							ForceNew:      true,
-							ConflictsWith: []string{"config.0.payload"},
+							ValidateFunc:  validateJSONObject,
+							ConflictsWith: []string{"config.0.payload", "config.0.payload_json"},
 							Description:   "Use instead of payload if the desired payload is more complex than a list of key/value pairs (e.g. a payload that makes use of nested objects). The value provided should be a valid JSON string with escaped double quotes. Conflicts with payload.",
 							// Suppress the diff shown if the differences are solely due to whitespace
 							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
@@ -181,60 +240,51 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 						"payload_type": {
 							Type:         schema.TypeString,
 							Optional:     true,
-							ForceNew:     true,
 							ValidateFunc: validation.StringInSlice([]string{"application/json", "application/x-www-form-urlencoded"}, false),
 							Description:  "Can either be application/json or application/x-www-form-urlencoded. The payload_type argument is required if payload is set.",
 						},
 						"recipients": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							ForceNew:    true,
 							Description: "A set of recipients for targeting notifications. Multiple values are comma separated.",
 						},
 						"region": {
 							Type:         schema.TypeString,
 							Optional:     true,
 							ValidateFunc: validation.StringInSlice([]string{"US", "EU"}, false),
-							ForceNew:     true,
 							Description:  "The data center region to store your data. Valid values are US and EU. Default is US.",
 						},
 						"route_key": {
 							Type:        schema.TypeString,
 							Optional:    true,
 							Sensitive:   true,
-							ForceNew:    true,
 							Description: "The route key for integrating with VictorOps.",
 						},
 						"service_key": {
 							Type:        schema.TypeString,
 							Optional:    true,
 							Sensitive:   true,
-							ForceNew:    true,
 							Description: "Specifies the service key for integrating with Pagerduty.",
 						},
 						"tags": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							ForceNew:    true,
 							Description: "A set of tags for targeting notifications. Multiple values are comma separated.",
 						},
 						"teams": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							ForceNew:    true,
 							Description: "A set of teams for targeting notifications. Multiple values are comma separated.",
 						},
 						"url": {
 							Type:        schema.TypeString,
 							Optional:    true,
 							Sensitive:   true,
-							ForceNew:    true,
 							Description: "Your organization's Slack URL.",
 						},
 						"user_id": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							ForceNew:    true,
 							Description: "The user ID for use with the user channel type.",
 						},
 					},
@@ -242,6 +292,187 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 			},
 		},
 	}
+
+	typedConfigs := alertChannelTypedConfigSchemas()
+	for name, config := range typedConfigs {
+		conflicts := []string{"config"}
+		for other := range typedConfigs {
+			if other != name {
+				conflicts = append(conflicts, other)
+			}
+		}
+
+		r.Schema[name] = &schema.Schema{
+			Type:          schema.TypeList,
+			Optional:      true,
+			MaxItems:      1,
+			ConflictsWith: conflicts,
+			Description:   fmt.Sprintf("The configuration block for alert channels of type %s.", name),
+			Elem:          &schema.Resource{Schema: config},
+		}
+	}
+
+	return r
+}
+
+// validateAlertChannelTypedConfig requires the configuration block named after
+// the type of the channel for types configured by a typed block, and rejects
+// typed blocks for other types.
+func validateAlertChannelTypedConfig(d *schema.ResourceDiff, meta interface{}) error {
+	channelType := d.Get("type").(string)
+	if channelType == "" {
+		return nil
+	}
+
+	for name := range alertChannelTypedConfigSchemas() {
+		config := d.Get(name).([]interface{})
+
+		if name == channelType && len(config) == 0 {
+			return fmt.Errorf("alert channel of type %s requires a %s block", channelType, name)
+		}
+
+		if name != channelType && len(config) > 0 {
+			return fmt.Errorf("the %s block is only supported by alert channels of type %s", name, name)
+		}
+	}
+
+	return nil
+}
+
+// alertChannelPayloadVariables are the variables New Relic replaces in the
+// payload of webhook channels.
+var alertChannelPayloadVariables = []string{
+	"$ACCOUNT_ID",
+	"$ACCOUNT_NAME",
+	"$CLOSED_VIOLATIONS_COUNT_CRITICAL",
+	"$CLOSED_VIOLATIONS_COUNT_WARNING",
+	"$CONDITION_DESCRIPTION",
+	"$CONDITION_FAMILY_ID",
+	"$CONDITION_ID",
+	"$CONDITION_NAME",
+	"$CURRENT_STATE",
+	"$DETAILS",
+	"$DURATION",
+	"$EVENT_DETAILS",
+	"$EVENT_STATE",
+	"$EVENT_TYPE",
+	"$INCIDENT_ACKNOWLEDGE_URL",
+	"$INCIDENT_ID",
+	"$INCIDENT_URL",
+	"$METADATA",
+	"$OPEN_VIOLATIONS_COUNT_CRITICAL",
+	"$OPEN_VIOLATIONS_COUNT_WARNING",
+	"$POLICY_ID",
+	"$POLICY_NAME",
+	"$POLICY_URL",
+	"$RUNBOOK_URL",
+	"$SEVERITY",
+	"$TARGETS",
+	"$TIMESTAMP",
+	"$TIMESTAMP_UTC_STRING",
+	"$VIOLATION_CALLBACK_URL",
+	"$VIOLATION_CHART_URL",
+}
+
+var alertChannelPayloadVariablePattern = regexp.MustCompile(`\$[A-Z][A-Z0-9_]*`)
+
+// validateAlertChannelPayload checks the payload of a webhook channel against
+// its payload_type and the variables New Relic replaces in it, which would
+// otherwise only fail once an incident is notified.
+func validateAlertChannelPayload(d *schema.ResourceDiff, meta interface{}) error {
+	for _, k := range []string{"config.0.payload", "config.0.payload_string", "config.0.payload_json", "config.0.payload_type"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	config := d.Get("config").([]interface{})
+	if len(config) == 0 || config[0] == nil {
+		return nil
+	}
+
+	cfg := config[0].(map[string]interface{})
+
+	// Payloads that are not valid JSON are reported by their ValidateFunc.
+	payload, err := expandAlertChannelPayload(cfg)
+	if err != nil || len(payload) == 0 {
+		return nil
+	}
+
+	switch cfg["payload_type"].(string) {
+	case "":
+		return fmt.Errorf("`payload_type` is required when using a payload")
+	case "application/x-www-form-urlencoded":
+		for k, v := range payload {
+			switch v.(type) {
+			case map[string]interface{}, []interface{}:
+				return fmt.Errorf("the payload value of %s must not be an object or array with the application/x-www-form-urlencoded `payload_type`", k)
+			}
+		}
+	}
+
+	return validateAlertChannelPayloadVariables(payload)
+}
+
+func validateAlertChannelPayloadVariables(value interface{}) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if err := validateAlertChannelPayloadVariables(k); err != nil {
+				return err
+			}
+
+			if err := validateAlertChannelPayloadVariables(v[k]); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if err := validateAlertChannelPayloadVariables(item); err != nil {
+				return err
+			}
+		}
+	case string:
+		for _, variable := range alertChannelPayloadVariablePattern.FindAllString(v, -1) {
+			if !stringInSlice(alertChannelPayloadVariables, variable) {
+				return fmt.Errorf("unknown payload variable %s, expected one of: %s", variable, strings.Join(alertChannelPayloadVariables, ", "))
+			}
+		}
+	}
+
+	return nil
+}
+
+// resourceImportStateWithAlertChannelType imports an alert channel by its ID,
+// or by its ID and type in the format <id>:<type> for webhook channels created
+// for one of the types the REST API has no type for, e.g. 12345:teams.
+func resourceImportStateWithAlertChannelType(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	ids := strings.SplitN(d.Id(), ":", 2)
+	if len(ids) == 1 {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	if _, ok := alertChannelWebhookTypes[ids[1]]; !ok {
+		return nil, fmt.Errorf("unsupported alert channel type %s in import ID %s", ids[1], d.Id())
+	}
+
+	d.SetId(ids[0])
+
+	if err := d.Set("type", ids[1]); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func isAlertChannelOfTypeUser(d *schema.ResourceDiff, meta interface{}) bool {
+	return d.Get("type").(string) == string(alerts.ChannelTypes.User)
 }
 
 func resourceNewRelicAlertChannelCreate(d *schema.ResourceData, meta interface{}) error {
@@ -286,6 +517,34 @@ func resourceNewRelicAlertChannelRead(d *schema.ResourceData, meta interface{})
 	return flattenAlertChannel(channel, d)
 }
 
+func resourceNewRelicAlertChannelUpdate(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+
+	channel, err := expandAlertChannel(d)
+	if err != nil {
+		return err
+	}
+
+	input, err := expandAlertChannelUpdateInput(channel)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Updating New Relic alert channel %v", id)
+
+	if err := updateAlertChannel(client, providerConfig.AccountID, id, input); err != nil {
+		return err
+	}
+
+	return resourceNewRelicAlertChannelRead(d, meta)
+}
+
 func resourceNewRelicAlertChannelDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ProviderConfig).NewClient
 
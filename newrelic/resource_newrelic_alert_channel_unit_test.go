@@ -0,0 +1,446 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccNewRelicAlertChannel_Offline(t *testing.T) {
+	resourceName := "newrelic_alert_channel.foo"
+	server := newTestFakeServer(t)
+	config := testAccNewRelicAlertChannelConfigOffline(server, "tf-test-channel")
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test-channel"),
+					resource.TestCheckResourceAttr(resourceName, "type", "email"),
+					resource.TestCheckResourceAttr(resourceName, "config.0.recipients", "terraform-acctest+foo@hashicorp.com"),
+					resource.TestCheckResourceAttr(resourceName, "config.0.include_json_attachment", "1"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            config,
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicAlertChannel_UpdateKeepsPoliciesOffline(t *testing.T) {
+	resourceName := "newrelic_alert_channel.foo"
+	server := newTestFakeServer(t)
+
+	var channelID int
+
+	// A policy the channel is attached to outside of Terraform.
+	otherPolicyID := 999999
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicAlertChannelWithPolicyConfigOffline(server, "terraform-acctest+foo@hashicorp.com"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicAlertChannelAttachedOffline(server, resourceName),
+					func(s *terraform.State) error {
+						id, err := strconv.Atoi(s.RootModule().Resources[resourceName].Primary.ID)
+						if err != nil {
+							return err
+						}
+
+						channelID = id
+
+						server.mu.Lock()
+						defer server.mu.Unlock()
+						server.linkChannel(channelID, otherPolicyID, true)
+
+						return nil
+					},
+				),
+			},
+			// Test: Changing the config updates the channel in place, keeping
+			// the policies it is attached to.
+			{
+				Config: testAccNewRelicAlertChannelWithPolicyConfigOffline(server, "terraform-acctest+bar@hashicorp.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "config.0.recipients", "terraform-acctest+bar@hashicorp.com"),
+					func(s *terraform.State) error {
+						if id := s.RootModule().Resources[resourceName].Primary.ID; id != strconv.Itoa(channelID) {
+							return fmt.Errorf("expected alert channel %d to be updated in place, got %s", channelID, id)
+						}
+
+						server.mu.Lock()
+						defer server.mu.Unlock()
+
+						policyIDs := server.channels[channelID]["links"].(testFakeObject)["policy_ids"].([]int)
+						if len(policyIDs) != 2 || !testFakeHasInt(policyIDs, otherPolicyID) {
+							return fmt.Errorf("expected alert channel %d to stay attached to its policies, got %v", channelID, policyIDs)
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicAlertChannel_UpdateWebhookOffline(t *testing.T) {
+	resourceName := "newrelic_alert_channel.foo"
+	server := newTestFakeServer(t)
+
+	var channelID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicAlertChannelWebhookConfigOffline(server, "https://example.com/webhook", "abc"),
+				Check: func(s *terraform.State) error {
+					channelID = s.RootModule().Resources[resourceName].Primary.ID
+					return nil
+				},
+			},
+			// Test: Update the URL and a header in place
+			{
+				Config: testAccNewRelicAlertChannelWebhookConfigOffline(server, "https://example.com/other", "def"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "config.0.base_url", "https://example.com/other"),
+					resource.TestCheckResourceAttr(resourceName, "config.0.headers.X-Token", "def"),
+					testAccCheckNewRelicAlertChannelWebhookOffline(server, resourceName, "https://example.com/other", "condition_name", "$CONDITION_NAME"),
+					func(s *terraform.State) error {
+						if id := s.RootModule().Resources[resourceName].Primary.ID; id != channelID {
+							return fmt.Errorf("expected alert channel %s to be updated in place, got %s", channelID, id)
+						}
+						return nil
+					},
+				),
+			},
+			// Test: No diff after the update
+			{
+				Config:   testAccNewRelicAlertChannelWebhookConfigOffline(server, "https://example.com/other", "def"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicAlertChannel_TeamsOffline(t *testing.T) {
+	resourceName := "newrelic_alert_channel.foo"
+	server := newTestFakeServer(t)
+	config := testAccNewRelicAlertChannelTeamsConfigOffline(server)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "type", "teams"),
+					resource.TestCheckResourceAttr(resourceName, "teams.0.url", "https://example.webhook.office.com/webhookb2/abc"),
+					resource.TestCheckResourceAttr(resourceName, "config.#", "0"),
+					testAccCheckNewRelicAlertChannelWebhookOffline(server, resourceName, "https://example.webhook.office.com/webhookb2/abc", "@type", "MessageCard"),
+				),
+			},
+			// Test: Import with the type
+			{
+				Config:            config,
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources[resourceName].Primary.ID + ":teams", nil
+				},
+			},
+			// Test: Import without the type reads a webhook
+			{
+				Config:       config,
+				ResourceName: resourceName,
+				ImportState:  true,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 || states[0].Attributes["type"] != "webhook" {
+						return fmt.Errorf("expected a webhook alert channel to be imported, got %v", states)
+					}
+					return nil
+				},
+			},
+			// Test: Import with an unsupported type
+			{
+				Config:        config,
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: "12345:jira",
+				ExpectError:   regexp.MustCompile(`unsupported alert channel type jira in import ID 12345:jira`),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicAlertChannel_TypedConfigMissingOffline(t *testing.T) {
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.providerConfig() + `
+resource "newrelic_alert_channel" "foo" {
+  name = "tf-test-channel"
+  type = "xmatters"
+
+  config {
+    base_url = "https://example.xmatters.com"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`alert channel of type xmatters requires a xmatters block`),
+			},
+			{
+				Config: server.providerConfig() + `
+resource "newrelic_alert_channel" "foo" {
+  name = "tf-test-channel"
+  type = "webhook"
+
+  teams {
+    url = "https://example.webhook.office.com/webhookb2/abc"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`the teams block is only supported by alert channels of type teams`),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicAlertChannel_PayloadJSONOffline(t *testing.T) {
+	resourceName := "newrelic_alert_channel.foo"
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicAlertChannelPayloadConfigOffline(server, "application/json", "payload_json", `{
+      "policy_name": "$POLICY_NAME",
+      "condition": {"name": "$CONDITION_NAME", "url": "$INCIDENT_URL"}
+    }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "config.0.payload_json", `{"condition":{"name":"$CONDITION_NAME","url":"$INCIDENT_URL"},"policy_name":"$POLICY_NAME"}`),
+					testAccCheckNewRelicAlertChannelWebhookOffline(server, resourceName, "https://example.com/webhook", "condition", map[string]interface{}{
+						"name": "$CONDITION_NAME",
+						"url":  "$INCIDENT_URL",
+					}),
+				),
+			},
+			// Test: Reformatting the payload is not a change
+			{
+				Config:   testAccNewRelicAlertChannelPayloadConfigOffline(server, "application/json", "payload_json", `{"condition": {"url": "$INCIDENT_URL", "name": "$CONDITION_NAME"}, "policy_name": "$POLICY_NAME"}`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicAlertChannel_PayloadValidationOffline(t *testing.T) {
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNewRelicAlertChannelPayloadConfigOffline(server, "application/json", "payload_string", `{"condition_name": "$CONDITION_NAME",}`),
+				ExpectError: regexp.MustCompile(`expected config.0.payload_string to be a JSON object`),
+			},
+			{
+				Config:      testAccNewRelicAlertChannelPayloadConfigOffline(server, "", "payload_json", `{"condition_name": "$CONDITION_NAME"}`),
+				ExpectError: regexp.MustCompile("`payload_type` is required when using a payload"),
+			},
+			{
+				Config:      testAccNewRelicAlertChannelPayloadConfigOffline(server, "application/x-www-form-urlencoded", "payload_json", `{"condition": {"name": "$CONDITION_NAME"}}`),
+				ExpectError: regexp.MustCompile(`the payload value of condition must not be an object or array`),
+			},
+			{
+				Config:      testAccNewRelicAlertChannelPayloadConfigOffline(server, "application/json", "payload_json", `{"condition": {"name": "$CONDITION"}}`),
+				ExpectError: regexp.MustCompile(`unknown payload variable \$CONDITION,`),
+			},
+		},
+	})
+}
+
+// testAccCheckNewRelicAlertChannelWebhookOffline verifies the channel is
+// created on the fake server as a webhook with the given URL and payload key.
+func testAccCheckNewRelicAlertChannelWebhookOffline(server *testFakeServer, n string, baseURL string, payloadKey string, payloadValue interface{}) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := strconv.Atoi(s.RootModule().Resources[n].Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		server.mu.Lock()
+		defer server.mu.Unlock()
+
+		channel := server.channels[id]
+		if channel["type"] != "webhook" {
+			return fmt.Errorf("expected alert channel %d to be a webhook, got %v", id, channel["type"])
+		}
+
+		config := channel["configuration"].(map[string]interface{})
+		if config["base_url"] != baseURL {
+			return fmt.Errorf("expected alert channel %d to post to %s, got %v", id, baseURL, config["base_url"])
+		}
+
+		payload := config["payload"].(map[string]interface{})
+		if !reflect.DeepEqual(payload[payloadKey], payloadValue) {
+			return fmt.Errorf("expected alert channel %d payload %s to be %v, got %v", id, payloadKey, payloadValue, payload[payloadKey])
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckNewRelicAlertChannelAttachedOffline verifies the channel is the
+// only one attached to the policy on the fake server.
+func testAccCheckNewRelicAlertChannelAttachedOffline(server *testFakeServer, n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		channelID, err := strconv.Atoi(s.RootModule().Resources[n].Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		policyID, err := strconv.Atoi(s.RootModule().Resources["newrelic_alert_policy.foo"].Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		server.mu.Lock()
+		defer server.mu.Unlock()
+
+		if ids := server.policyChannelIDs(policyID); len(ids) != 1 || ids[0] != channelID {
+			return fmt.Errorf("expected policy %d to have channel %d attached, got %v", policyID, channelID, ids)
+		}
+
+		return nil
+	}
+}
+
+func testAccNewRelicAlertChannelConfigOffline(server *testFakeServer, name string) string {
+	return server.providerConfig() + fmt.Sprintf(`
+resource "newrelic_alert_channel" "foo" {
+  name = "%s"
+  type = "email"
+
+  config {
+    recipients              = "terraform-acctest+foo@hashicorp.com"
+    include_json_attachment = "1"
+  }
+}
+`, name)
+}
+
+func testAccNewRelicAlertChannelWithPolicyConfigOffline(server *testFakeServer, recipients string) string {
+	return server.providerConfig() + fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-policy"
+}
+
+resource "newrelic_alert_channel" "foo" {
+  name = "tf-test-channel"
+  type = "email"
+
+  config {
+    recipients = "%s"
+  }
+}
+
+resource "newrelic_alert_policy_channel" "foo" {
+  policy_id   = newrelic_alert_policy.foo.id
+  channel_ids = [newrelic_alert_channel.foo.id]
+}
+`, recipients)
+}
+
+func testAccNewRelicAlertChannelWebhookConfigOffline(server *testFakeServer, baseURL string, token string) string {
+	return server.providerConfig() + fmt.Sprintf(`
+resource "newrelic_alert_channel" "foo" {
+  name = "tf-test-channel"
+  type = "webhook"
+
+  config {
+    base_url     = "%s"
+    payload_type = "application/json"
+    headers = {
+      X-Token = "%s"
+    }
+    payload = {
+      condition_name = "$CONDITION_NAME"
+    }
+  }
+}
+`, baseURL, token)
+}
+
+func testAccNewRelicAlertChannelTeamsConfigOffline(server *testFakeServer) string {
+	return server.providerConfig() + `
+resource "newrelic_alert_channel" "foo" {
+  name = "tf-test-channel"
+  type = "teams"
+
+  teams {
+    url = "https://example.webhook.office.com/webhookb2/abc"
+  }
+}
+`
+}
+
+func testAccNewRelicAlertChannelPayloadConfigOffline(server *testFakeServer, payloadType string, payloadAttr string, payload string) string {
+	if payloadType != "" {
+		payloadType = fmt.Sprintf("payload_type = %q", payloadType)
+	}
+
+	return server.providerConfig() + fmt.Sprintf(`
+resource "newrelic_alert_channel" "foo" {
+  name = "tf-test-channel"
+  type = "webhook"
+
+  config {
+    base_url = "https://example.com/webhook"
+    %s
+    %s = <<EOF
+%s
+EOF
+  }
+}
+`, payloadType, payloadAttr, payload)
+}
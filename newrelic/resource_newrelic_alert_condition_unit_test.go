@@ -76,3 +76,66 @@ func TestAccNewRelicAlertCondition_EmptyName(t *testing.T) {
 		},
 	})
 }
+
+func TestAccNewRelicAlertCondition_Offline(t *testing.T) {
+	resourceName := "newrelic_alert_condition.foo"
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicAlertConditionConfigOffline(server, "tf-test-condition", 0.75),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test-condition"),
+					resource.TestCheckResourceAttr(resourceName, "entities.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "term.#", "1"),
+				),
+			},
+			// Test: Update
+			{
+				Config: testAccNewRelicAlertConditionConfigOffline(server, "tf-test-condition-updated", 0.5),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test-condition-updated"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            testAccNewRelicAlertConditionConfigOffline(server, "tf-test-condition-updated", 0.5),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNewRelicAlertConditionConfigOffline(server *testFakeServer, name string, threshold float64) string {
+	return server.providerConfig() + fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-policy"
+}
+
+resource "newrelic_alert_condition" "foo" {
+  policy_id = newrelic_alert_policy.foo.id
+
+  name            = "%s"
+  type            = "apm_app_metric"
+  entities        = [12345]
+  metric          = "apdex"
+  runbook_url     = "https://foo.example.com"
+  condition_scope = "application"
+
+  term {
+    duration      = 5
+    operator      = "below"
+    priority      = "critical"
+    threshold     = %g
+    time_function = "all"
+  }
+}
+`, name, threshold)
+}
@@ -1,7 +1,9 @@
 package newrelic
 
 import (
+	"fmt"
 	"log"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
@@ -82,15 +84,123 @@ func resourceNewRelicAlertMutingRule() *schema.Resource {
 				Optional:    true,
 				Description: "The description of the MutingRule.",
 			},
+			"schedule": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "The time window when the MutingRule should actively mute violations.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start_time": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateNaiveDateTime,
+							Description:  "The datetime stamp when the MutingRule schedule should start, in the format YYYY-MM-DDThh:mm:ss (no time zone offset).",
+						},
+						"end_time": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateNaiveDateTime,
+							Description:  "The datetime stamp when the MutingRule schedule should end, in the format YYYY-MM-DDThh:mm:ss (no time zone offset).",
+						},
+						"time_zone": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateTimeZone,
+							Description:  "The time zone that applies to the MutingRule schedule, e.g. America/Los_Angeles.",
+						},
+						"repeat": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"DAILY", "WEEKLY", "MONTHLY"}, false),
+							Description:  "The frequency the MutingRule schedule repeats. One of DAILY, WEEKLY or MONTHLY. If omitted, the schedule does not repeat.",
+						},
+						"end_repeat": {
+							Type:          schema.TypeString,
+							Optional:      true,
+							ValidateFunc:  validateNaiveDateTime,
+							ConflictsWith: []string{"schedule.0.repeat_count"},
+							Description:   "The datetime stamp when the MutingRule schedule stops repeating, in the format YYYY-MM-DDThh:mm:ss (no time zone offset).",
+						},
+						"repeat_count": {
+							Type:          schema.TypeInt,
+							Optional:      true,
+							ValidateFunc:  validation.IntAtLeast(1),
+							ConflictsWith: []string{"schedule.0.end_repeat"},
+							Description:   "The number of times the MutingRule schedule should repeat.",
+						},
+						"weekly_repeat_days": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							MaxItems:    7,
+							Description: "The day(s) of the week that a MutingRule should repeat when the repeat field is set to WEEKLY.",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{"MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY", "SUNDAY"}, false),
+							},
+						},
+					},
+				},
+			},
 		},
+		CustomizeDiff: validateMutingRuleSchedule,
+	}
+}
+
+// validateMutingRuleSchedule checks the cross-field rules of the schedule block
+// that cannot be expressed with ValidateFunc alone.
+func validateMutingRuleSchedule(d *schema.ResourceDiff, meta interface{}) error {
+	if _, ok := d.GetOk("schedule"); !ok {
+		return nil
+	}
+
+	repeat := d.Get("schedule.0.repeat").(string)
+	weeklyRepeatDays := d.Get("schedule.0.weekly_repeat_days").(*schema.Set)
+
+	if weeklyRepeatDays.Len() > 0 && repeat != "WEEKLY" {
+		return fmt.Errorf("`weekly_repeat_days` can only be set when `repeat` is WEEKLY")
+	}
+
+	if repeat == "" {
+		if _, ok := d.GetOk("schedule.0.end_repeat"); ok {
+			return fmt.Errorf("`end_repeat` requires `repeat` to be set")
+		}
+
+		if _, ok := d.GetOk("schedule.0.repeat_count"); ok {
+			return fmt.Errorf("`repeat_count` requires `repeat` to be set")
+		}
+	}
+
+	startTime, startOk := d.GetOk("schedule.0.start_time")
+	endTime, endOk := d.GetOk("schedule.0.end_time")
+
+	if startOk && endOk {
+		start, err := time.Parse(naiveDateTimeFormat, startTime.(string))
+		if err != nil {
+			return err
+		}
+
+		end, err := time.Parse(naiveDateTimeFormat, endTime.(string))
+		if err != nil {
+			return err
+		}
+
+		if !end.After(start) {
+			return fmt.Errorf("`end_time` must be after `start_time`")
+		}
 	}
+
+	return nil
 }
 
 func resourceNewRelicAlertMutingRuleCreate(d *schema.ResourceData, meta interface{}) error {
 	providerConfig := meta.(*ProviderConfig)
 	client := providerConfig.NewClient
 
-	createInput := expandMutingRuleCreateInput(d)
+	createInput, err := expandMutingRuleCreateInput(d)
+	if err != nil {
+		return err
+	}
 
 	accountID := selectAccountID(providerConfig, d)
 
@@ -134,7 +244,10 @@ func resourceNewRelicAlertMutingRuleRead(d *schema.ResourceData, meta interface{
 
 func resourceNewRelicAlertMutingRuleUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ProviderConfig).NewClient
-	updateInput := expandMutingRuleUpdateInput(d)
+	updateInput, err := expandMutingRuleUpdateInput(d)
+	if err != nil {
+		return err
+	}
 
 	log.Printf("[INFO] Updating New Relic One alert muting rule.")
 
@@ -146,7 +259,7 @@ func resourceNewRelicAlertMutingRuleUpdate(d *schema.ResourceData, meta interfac
 	accountID := ids[0]
 	mutingRuleID := ids[1]
 
-	_, err = client.Alerts.UpdateMutingRule(accountID, mutingRuleID, updateInput)
+	err = updateMutingRule(client, accountID, mutingRuleID, updateInput)
 	if err != nil {
 		d.SetId("")
 		return nil
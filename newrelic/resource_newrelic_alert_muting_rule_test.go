@@ -44,6 +44,70 @@ func TestAccNewRelicAlertMutingRule_Basic(t *testing.T) {
 	})
 }
 
+func TestAccNewRelicAlertMutingRule_WithSchedule(t *testing.T) {
+	resourceName := "newrelic_alert_muting_rule.foo"
+	rName := acctest.RandString(5)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicAlertMutingRuleDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicAlertMutingRuleWithSchedule(rName, "DAILY", `repeat_count = 3`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicAlertMutingRuleExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "schedule.0.repeat", "DAILY"),
+					resource.TestCheckResourceAttr(resourceName, "schedule.0.repeat_count", "3"),
+				),
+			},
+			// Test: Update
+			{
+				Config: testAccNewRelicAlertMutingRuleWithSchedule(rName, "WEEKLY", `weekly_repeat_days = ["MONDAY", "FRIDAY"]
+		end_repeat         = "2031-06-30T00:00:00"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicAlertMutingRuleExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "schedule.0.repeat", "WEEKLY"),
+					resource.TestCheckResourceAttr(resourceName, "schedule.0.weekly_repeat_days.#", "2"),
+				),
+			},
+			// Test: Import
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNewRelicAlertMutingRuleWithSchedule(name string, repeat string, repeatAttrs string) string {
+	return fmt.Sprintf(`
+
+resource "newrelic_alert_muting_rule" "foo" {
+	name = "tf-test-%[1]s"
+	enabled = true
+	description = "muting rule with schedule"
+	condition {
+		conditions {
+			attribute 	= "conditionType"
+			operator 	= "EQUALS"
+			values 		= ["static"]
+		}
+		operator = "AND"
+	}
+	schedule {
+		start_time = "2031-01-01T15:00:00"
+		end_time   = "2031-01-01T16:00:00"
+		time_zone  = "America/Los_Angeles"
+		repeat     = "%[2]s"
+		%[3]s
+	}
+}
+`, name, repeat, repeatAttrs)
+}
+
 func testAccNewRelicAlertMutingRuleBasic(
 	name string,
 	description string,
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/newrelic/newrelic-client-go/newrelic"
 	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+	"github.com/newrelic/newrelic-client-go/pkg/entities"
 	nrErrors "github.com/newrelic/newrelic-client-go/pkg/errors"
 )
 
@@ -20,8 +21,9 @@ func resourceNewRelicAlertPolicy() *schema.Resource {
 		Update: resourceNewRelicAlertPolicyUpdate,
 		Delete: resourceNewRelicAlertPolicyDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceImportStateWithMetadata(1, "account_id"),
+			State: resourceNewRelicAlertPolicyImport,
 		},
+		CustomizeDiff: customizeDiffDefaultTags,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
@@ -48,9 +50,9 @@ func resourceNewRelicAlertPolicy() *schema.Resource {
 					Type: schema.TypeInt,
 				},
 				Optional:    true,
-				ForceNew:    true,
-				Description: "An array of channel IDs (integers) to assign to the policy. Adding or removing channel IDs from this array will result in a new alert policy resource being created and the old one being destroyed. Also note that channel IDs cannot be imported via terraform import.",
+				Description: "An array of channel IDs (integers) to assign to the policy. Channels added to or removed from this array are attached to or detached from the existing policy.",
 			},
+			"default_tags": defaultTagsSchema(),
 		},
 	}
 }
@@ -88,6 +90,16 @@ func resourceNewRelicAlertPolicyCreate(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
+	guid := alertPolicyGUID(accountID, createResult.ID)
+
+	if err := updateDefaultTags(d, meta, guid); err != nil {
+		return err
+	}
+
+	if err := readDefaultTags(d, meta, guid); err != nil {
+		return err
+	}
+
 	channels := d.Get("channel_ids").([]interface{})
 
 	if len(channels) > 0 {
@@ -155,7 +167,27 @@ func resourceNewRelicAlertPolicyRead(d *schema.ResourceData, meta interface{}) e
 		return queryErr
 	}
 
-	return flattenAlertPolicy(queryPolicy, d, accountID)
+	if err := flattenAlertPolicy(queryPolicy, d, accountID); err != nil {
+		return err
+	}
+
+	if err := readDefaultTags(d, meta, alertPolicyGUID(accountID, id)); err != nil {
+		return err
+	}
+
+	// Channels attached to the policy outside of channel_ids, e.g. with
+	// newrelic_alert_policy_channel, are left alone.
+	channels := d.Get("channel_ids").([]interface{})
+	if len(channels) == 0 {
+		return nil
+	}
+
+	channelIDs, err := findAttachedChannelIDs(client, accountID, policyID, expandAlertChannelIDs(channels))
+	if err != nil {
+		return err
+	}
+
+	return d.Set("channel_ids", channelIDs)
 }
 
 func resourceNewRelicAlertPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
@@ -188,7 +220,60 @@ func resourceNewRelicAlertPolicyUpdate(d *schema.ResourceData, meta interface{})
 		return updateErr
 	}
 
-	return flattenAlertPolicy(updateResult, d, accountID)
+	if err := flattenAlertPolicy(updateResult, d, accountID); err != nil {
+		return err
+	}
+
+	if err := updateDefaultTags(d, meta, alertPolicyGUID(accountID, updateResult.ID)); err != nil {
+		return err
+	}
+
+	if d.HasChange("channel_ids") {
+		policyID, err := strconv.Atoi(d.Id())
+		if err != nil {
+			return err
+		}
+
+		o, n := d.GetChange("channel_ids")
+		oldChannelIDs := expandAlertChannelIDs(o.([]interface{}))
+		newChannelIDs := expandAlertChannelIDs(n.([]interface{}))
+
+		var addChannelIDs []int
+		for _, id := range newChannelIDs {
+			if !intSliceContains(oldChannelIDs, id) {
+				addChannelIDs = append(addChannelIDs, id)
+			}
+		}
+
+		if len(addChannelIDs) > 0 {
+			matchedChannelIDs, err := findExistingChannelIDs(client, addChannelIDs)
+			if err != nil {
+				return err
+			}
+
+			log.Printf("[INFO] Adding channels %+v to policy %d", matchedChannelIDs, policyID)
+
+			if _, err := client.Alerts.UpdatePolicyChannels(policyID, matchedChannelIDs); err != nil {
+				return err
+			}
+		}
+
+		for _, id := range oldChannelIDs {
+			if intSliceContains(newChannelIDs, id) {
+				continue
+			}
+
+			log.Printf("[INFO] Removing channel %d from policy %d", id, policyID)
+
+			if _, err := client.Alerts.DeletePolicyChannel(policyID, id); err != nil {
+				if _, ok := err.(*nrErrors.NotFound); !ok {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
 }
 
 func resourceNewRelicAlertPolicyDelete(d *schema.ResourceData, meta interface{}) error {
@@ -212,6 +297,38 @@ func resourceNewRelicAlertPolicyDelete(d *schema.ResourceData, meta interface{})
 	return nil
 }
 
+// resourceNewRelicAlertPolicyImport fills in channel_ids with every channel
+// attached to the imported policy.
+func resourceNewRelicAlertPolicyImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	results, err := resourceImportStateWithMetadata(1, "account_id")(d, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := parseHashedIDs(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	channelIDs, err := findPolicyChannelIDs(meta.(*ProviderConfig).NewClient, ids[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(channelIDs) > 0 {
+		if err := d.Set("channel_ids", channelIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// alertPolicyGUID returns the entity GUID of an alert policy.
+func alertPolicyGUID(accountID int, policyID string) entities.EntityGUID {
+	return entityGUID(accountID, "AIOPS", "POLICY", policyID)
+}
+
 func findExistingChannelIDs(client *newrelic.NewRelic, channelIDs []int) ([]int, error) {
 	channels, err := client.Alerts.ListChannels()
 
@@ -221,13 +338,35 @@ func findExistingChannelIDs(client *newrelic.NewRelic, channelIDs []int) ([]int,
 
 	matched := make([]int, 0)
 
-	for i := range channels {
-		for n := range channelIDs {
-			if channelIDs[n] == channels[i].ID {
-				matched = append(matched, channelIDs[n])
-			}
+	for n := range channelIDs {
+		if findChannel(channels, channelIDs[n]) == nil {
+			return nil, fmt.Errorf("alert channel %d not found", channelIDs[n])
 		}
+
+		matched = append(matched, channelIDs[n])
 	}
 
 	return matched, nil
 }
+
+// findPolicyChannelIDs returns the IDs of the channels attached to a policy, in
+// ascending order. It lists every channel of the account, so it is only used
+// on import, when the channels are not known yet.
+func findPolicyChannelIDs(client *newrelic.NewRelic, policyID int) ([]int, error) {
+	channels, err := client.Alerts.ListChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	channelIDs := []int{}
+
+	for _, channel := range channels {
+		if intSliceContains(channel.Links.PolicyIDs, policyID) {
+			channelIDs = append(channelIDs, channel.ID)
+		}
+	}
+
+	sortIntegerSlice(channelIDs)
+
+	return channelIDs, nil
+}
@@ -0,0 +1,408 @@
+package newrelic
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+	nrErrors "github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+func resourceNewRelicAlertPolicyBundle() *schema.Resource {
+	policy := resourceNewRelicAlertPolicy()
+
+	return &schema.Resource{
+		Create: resourceNewRelicAlertPolicyBundleCreate,
+		Read:   resourceNewRelicAlertPolicyBundleRead,
+		Update: resourceNewRelicAlertPolicyBundleUpdate,
+		Delete: resourceNewRelicAlertPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceNewRelicAlertPolicyBundleImport,
+		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffDefaultTags,
+			validateAlertPolicyBundleConditionNames,
+			validateAlertPolicyBundleConditions,
+		),
+		Schema: map[string]*schema.Schema{
+			"name":                policy.Schema["name"],
+			"account_id":          policy.Schema["account_id"],
+			"incident_preference": policy.Schema["incident_preference"],
+			"channel_ids":         policy.Schema["channel_ids"],
+			"default_tags":        policy.Schema["default_tags"],
+			"nrql_condition": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The NRQL alert conditions of the policy. Conditions are matched with the existing conditions of the policy by name.",
+				Elem: &schema.Resource{
+					Schema: alertPolicyBundleConditionSchema(),
+				},
+			},
+		},
+	}
+}
+
+// alertPolicyBundleConditionSchema is the schema of a
+// newrelic_nrql_alert_condition without its policy and account, which are the
+// bundle's, and without its deprecated attributes.
+func alertPolicyBundleConditionSchema() map[string]*schema.Schema {
+	conditionSchema := inlineSchema(nrqlAlertConditionSchema())
+
+	delete(conditionSchema, "policy_id")
+	delete(conditionSchema, "account_id")
+
+	conditionSchema["name"].ValidateFunc = validation.NoZeroValues
+	conditionSchema["violation_time_limit_seconds"].Computed = true
+
+	conditionSchema["condition_id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The ID of the condition.",
+	}
+
+	return conditionSchema
+}
+
+// inlineSchema copies the schema of a resource for use in a nested block,
+// without its deprecated attributes and without the references between
+// attributes and ForceNew, which only apply at the top level. The references
+// are checked by validateInlineConflicts instead.
+func inlineSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	inline := make(map[string]*schema.Schema, len(s))
+
+	for k, v := range s {
+		if v.Deprecated != "" {
+			continue
+		}
+
+		attr := *v
+		attr.ForceNew = false
+		attr.ConflictsWith = nil
+		attr.RequiredWith = nil
+		attr.ExactlyOneOf = nil
+		attr.AtLeastOneOf = nil
+
+		if elem, ok := v.Elem.(*schema.Resource); ok {
+			attr.Elem = &schema.Resource{Schema: inlineSchema(elem.Schema)}
+		}
+
+		inline[k] = &attr
+	}
+
+	return inline
+}
+
+// validateAlertPolicyBundleConditionNames checks that the names of the
+// conditions are unique, as conditions are matched by name.
+func validateAlertPolicyBundleConditionNames(d *schema.ResourceDiff, meta interface{}) error {
+	names := map[string]bool{}
+
+	for _, c := range d.Get("nrql_condition").([]interface{}) {
+		name := c.(map[string]interface{})["name"].(string)
+		if name == "" {
+			continue
+		}
+
+		if names[name] {
+			return fmt.Errorf("the names of `nrql_condition` blocks must be unique, got %s more than once", name)
+		}
+
+		names[name] = true
+	}
+
+	return nil
+}
+
+// validateAlertPolicyBundleConditions runs the validations of
+// newrelic_nrql_alert_condition on each inline condition.
+func validateAlertPolicyBundleConditions(d *schema.ResourceDiff, meta interface{}) error {
+	conditionSchema := nrqlAlertConditionSchema()
+
+	for i, c := range d.Get("nrql_condition").([]interface{}) {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		data, err := alertPolicyBundleConditionData(condition)
+		if err != nil {
+			return err
+		}
+
+		conditionDiff := alertPolicyBundleConditionDiff{
+			ResourceData: data,
+			diff:         d,
+			prefix:       fmt.Sprintf("nrql_condition.%d.", i),
+		}
+
+		if err := validateInlineConflicts(conditionDiff, conditionSchema); err != nil {
+			return fmt.Errorf("error in `nrql_condition` %q: %s", condition["name"], err)
+		}
+
+		if err := validateNrqlCondition(conditionDiff); err != nil {
+			return fmt.Errorf("error in `nrql_condition` %q: %s", condition["name"], err)
+		}
+	}
+
+	return nil
+}
+
+// validateInlineConflicts checks the ConflictsWith references of a schema that
+// inlineSchema leaves out, on the values of a nested block.
+func validateInlineConflicts(d nrqlConditionDiff, s map[string]*schema.Schema) error {
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if _, ok := d.GetOk(k); !ok {
+			continue
+		}
+
+		for _, conflict := range s[k].ConflictsWith {
+			if _, ok := d.GetOk(conflict); ok {
+				return fmt.Errorf("`%s` conflicts with `%s`", k, conflict)
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceNewRelicAlertPolicyBundleCreate(d *schema.ResourceData, meta interface{}) error {
+	if err := resourceNewRelicAlertPolicyCreate(d, meta); err != nil {
+		return err
+	}
+
+	if err := reconcileAlertPolicyBundleConditions(d, meta); err != nil {
+		return err
+	}
+
+	return resourceNewRelicAlertPolicyBundleRead(d, meta)
+}
+
+func resourceNewRelicAlertPolicyBundleRead(d *schema.ResourceData, meta interface{}) error {
+	if err := resourceNewRelicAlertPolicyRead(d, meta); err != nil {
+		return err
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Reading NRQL alert conditions of New Relic alert policy %s", d.Id())
+
+	conditions := []interface{}{}
+
+	for _, c := range d.Get("nrql_condition").([]interface{}) {
+		condition := c.(map[string]interface{})
+
+		conditionID := condition["condition_id"].(string)
+		if conditionID == "" {
+			continue
+		}
+
+		nrqlCondition, err := getNrqlCondition(client, accountID, conditionID)
+		if err != nil {
+			// Conditions deleted outside of Terraform are created again.
+			if _, ok := err.(*nrErrors.NotFound); ok {
+				continue
+			}
+
+			return err
+		}
+
+		flattened, err := flattenAlertPolicyBundleCondition(accountID, nrqlCondition, condition)
+		if err != nil {
+			return err
+		}
+
+		conditions = append(conditions, flattened)
+	}
+
+	return d.Set("nrql_condition", conditions)
+}
+
+func resourceNewRelicAlertPolicyBundleUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChanges("name", "incident_preference", "channel_ids", "default_tags") {
+		if err := resourceNewRelicAlertPolicyUpdate(d, meta); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("nrql_condition") {
+		if err := reconcileAlertPolicyBundleConditions(d, meta); err != nil {
+			return err
+		}
+	}
+
+	return resourceNewRelicAlertPolicyBundleRead(d, meta)
+}
+
+// reconcileAlertPolicyBundleConditions creates, updates and deletes the NRQL
+// conditions of the policy, matching the configured conditions with the
+// existing ones by name. A condition whose type changes is replaced, as
+// NerdGraph can't change the type of a condition.
+func reconcileAlertPolicyBundleConditions(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return errors.New("err: NerdGraph support not present, but required for NRQL conditions")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	o, n := d.GetChange("nrql_condition")
+
+	existing := map[string]map[string]interface{}{}
+	for _, c := range o.([]interface{}) {
+		condition := c.(map[string]interface{})
+		existing[condition["name"].(string)] = condition
+	}
+
+	var conditions []interface{}
+
+	// On error, keep the conditions that exist in state so that they are not
+	// orphaned.
+	fail := func(err error) error {
+		for _, name := range sortedAlertPolicyBundleConditionNames(existing) {
+			conditions = append(conditions, existing[name])
+		}
+
+		if setErr := d.Set("nrql_condition", conditions); setErr != nil {
+			log.Printf("[WARN] Error setting NRQL alert conditions of New Relic alert policy %s: %s", d.Id(), setErr)
+		}
+
+		return err
+	}
+
+	for _, c := range n.([]interface{}) {
+		condition := c.(map[string]interface{})
+		name := condition["name"].(string)
+		conditionType := condition["type"].(string)
+
+		conditionData, err := alertPolicyBundleConditionData(condition)
+		if err != nil {
+			return fail(err)
+		}
+
+		input, err := expandNrqlAlertConditionInput(conditionData)
+		if err != nil {
+			return fail(fmt.Errorf("error expanding NRQL alert condition %s: %s", name, err))
+		}
+
+		prior, hasPrior := existing[name]
+
+		conditionID := ""
+		if hasPrior {
+			conditionID = prior["condition_id"].(string)
+
+			if conditionID != "" && prior["type"].(string) != conditionType {
+				if err := deleteAlertPolicyBundleCondition(client, accountID, conditionID); err != nil {
+					return fail(err)
+				}
+
+				conditionID = ""
+			}
+
+			delete(existing, name)
+		}
+
+		if conditionID == "" {
+			log.Printf("[INFO] Creating New Relic NRQL alert condition %s on policy %s", name, d.Id())
+
+			if conditionID, err = createNrqlCondition(client, accountID, d.Id(), conditionType, input); err != nil {
+				return fail(err)
+			}
+		} else {
+			log.Printf("[INFO] Updating New Relic NRQL alert condition %s on policy %s", conditionID, d.Id())
+
+			if err := updateNrqlCondition(client, accountID, conditionID, conditionType, input); err != nil {
+				conditions = append(conditions, prior)
+				return fail(err)
+			}
+		}
+
+		condition["condition_id"] = conditionID
+		conditions = append(conditions, condition)
+	}
+
+	for _, name := range sortedAlertPolicyBundleConditionNames(existing) {
+		if conditionID := existing[name]["condition_id"].(string); conditionID != "" {
+			if err := deleteAlertPolicyBundleCondition(client, accountID, conditionID); err != nil {
+				return fail(err)
+			}
+		}
+
+		delete(existing, name)
+	}
+
+	return d.Set("nrql_condition", conditions)
+}
+
+func deleteAlertPolicyBundleCondition(client *newrelic.NewRelic, accountID int, conditionID string) error {
+	log.Printf("[INFO] Deleting New Relic NRQL alert condition %s", conditionID)
+
+	if _, err := client.Alerts.DeleteNrqlConditionMutation(accountID, conditionID); err != nil {
+		if _, ok := err.(*nrErrors.NotFound); !ok {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resourceNewRelicAlertPolicyBundleImport fills in channel_ids and
+// nrql_condition with every channel and NRQL condition of the imported policy,
+// with the conditions ordered by name.
+func resourceNewRelicAlertPolicyBundleImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	results, err := resourceNewRelicAlertPolicyImport(d, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	providerConfig := meta.(*ProviderConfig)
+
+	ids, err := parseHashedIDs(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	nrqlConditions, err := providerConfig.NewClient.Alerts.SearchNrqlConditionsQuery(selectAccountID(providerConfig, d), alerts.NrqlConditionsSearchCriteria{
+		PolicyID: strconv.Itoa(ids[0]),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(nrqlConditions, func(i, j int) bool {
+		return nrqlConditions[i].Name < nrqlConditions[j].Name
+	})
+
+	conditions := make([]interface{}, 0, len(nrqlConditions))
+	for _, c := range nrqlConditions {
+		conditions = append(conditions, map[string]interface{}{
+			"condition_id": c.ID,
+		})
+	}
+
+	if err := d.Set("nrql_condition", conditions); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,307 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccNewRelicAlertPolicyBundle_Offline(t *testing.T) {
+	resourceName := "newrelic_alert_policy_bundle.foo"
+	server := newTestFakeServer(t)
+
+	var errorRateID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicAlertPolicyBundleConfigOffline(server,
+					testAccNewRelicAlertPolicyBundleConditionConfig("Throughput", "static", 10),
+					testAccNewRelicAlertPolicyBundleConditionConfig("Error rate", "static", 5),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test-bundle"),
+					resource.TestCheckResourceAttr(resourceName, "channel_ids.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "nrql_condition.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "nrql_condition.0.name", "Throughput"),
+					resource.TestCheckResourceAttr(resourceName, "nrql_condition.1.critical.0.threshold", "5"),
+					resource.TestCheckResourceAttrSet(resourceName, "nrql_condition.0.condition_id"),
+					testAccCheckNewRelicAlertPolicyBundleConditionsOffline(server, resourceName, "Error rate", "Throughput"),
+					func(s *terraform.State) error {
+						errorRateID = s.RootModule().Resources[resourceName].Primary.Attributes["nrql_condition.1.condition_id"]
+						return nil
+					},
+				),
+			},
+			// Test: Remove a condition, add another and update one
+			{
+				Config: testAccNewRelicAlertPolicyBundleConfigOffline(server,
+					testAccNewRelicAlertPolicyBundleConditionConfig("Error rate", "static", 7),
+					testAccNewRelicAlertPolicyBundleConditionConfig("Response time", "baseline", 3),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "nrql_condition.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "nrql_condition.0.name", "Error rate"),
+					resource.TestCheckResourceAttr(resourceName, "nrql_condition.0.critical.0.threshold", "7"),
+					resource.TestCheckResourceAttr(resourceName, "nrql_condition.1.type", "baseline"),
+					testAccCheckNewRelicAlertPolicyBundleConditionsOffline(server, resourceName, "Error rate", "Response time"),
+					func(s *terraform.State) error {
+						if id := s.RootModule().Resources[resourceName].Primary.Attributes["nrql_condition.0.condition_id"]; id != errorRateID {
+							return fmt.Errorf("expected condition %s to be updated, got condition %s", errorRateID, id)
+						}
+						return nil
+					},
+				),
+			},
+			// Test: Change the type of a condition
+			{
+				Config: testAccNewRelicAlertPolicyBundleConfigOffline(server,
+					testAccNewRelicAlertPolicyBundleConditionConfig("Error rate", "baseline", 7),
+					testAccNewRelicAlertPolicyBundleConditionConfig("Response time", "baseline", 3),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "nrql_condition.0.type", "baseline"),
+					testAccCheckNewRelicAlertPolicyBundleConditionsOffline(server, resourceName, "Error rate", "Response time"),
+				),
+			},
+			// Test: Import
+			{
+				Config: testAccNewRelicAlertPolicyBundleConfigOffline(server,
+					testAccNewRelicAlertPolicyBundleConditionConfig("Error rate", "baseline", 7),
+					testAccNewRelicAlertPolicyBundleConditionConfig("Response time", "baseline", 3),
+				),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicAlertPolicyBundle_DuplicateNamesOffline(t *testing.T) {
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNewRelicAlertPolicyBundleConfigOffline(server,
+					testAccNewRelicAlertPolicyBundleConditionConfig("Error rate", "static", 5),
+					testAccNewRelicAlertPolicyBundleConditionConfig("Error rate", "static", 7),
+				),
+				ExpectError: regexp.MustCompile("the names of `nrql_condition` blocks must be unique, got Error rate more than once"),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicAlertPolicyBundle_ZeroValuesOffline(t *testing.T) {
+	resourceName := "newrelic_alert_policy_bundle.foo"
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNewRelicAlertPolicyBundleConfigOffline(server,
+					testAccNewRelicAlertPolicyBundleCustomConditionConfig(`
+    fill_option        = "static"
+    fill_value         = 0
+    aggregation_window = 60
+`),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "nrql_condition.0.fill_option", "static"),
+					resource.TestCheckResourceAttr(resourceName, "nrql_condition.0.fill_value", "0"),
+					testAccCheckNewRelicAlertPolicyBundleFillValueOffline(server, resourceName, 0),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicAlertPolicyBundle_ValidationOffline(t *testing.T) {
+	server := newTestFakeServer(t)
+
+	cases := []struct {
+		attrs    string
+		expected string
+	}{
+		{
+			attrs:    `expected_groups = 2`,
+			expected: "error in `nrql_condition` \"Error rate\": `expected_groups` can only be used with outlier conditions",
+		},
+		{
+			attrs:    `fill_value = 1`,
+			expected: "`fill_value` can only be used when `fill_option` is static",
+		},
+		{
+			attrs:    `baseline_direction = "upper_only"`,
+			expected: "`baseline_direction` conflicts with `value_function`",
+		},
+		{
+			attrs: `
+    aggregation_method = "event_flow"
+    aggregation_delay  = "60"
+    aggregation_timer  = 60
+`,
+			expected: "`aggregation_delay` conflicts with `aggregation_timer`",
+		},
+		{
+			attrs:    `aggregation_window = 120`,
+			expected: "`threshold_duration` of the critical term must be a multiple of `aggregation_window` \\(120\\), got: 300",
+		},
+	}
+
+	steps := make([]resource.TestStep, 0, len(cases))
+	for _, c := range cases {
+		steps = append(steps, resource.TestStep{
+			Config:      testAccNewRelicAlertPolicyBundleConfigOffline(server, testAccNewRelicAlertPolicyBundleCustomConditionConfig(c.attrs)),
+			ExpectError: regexp.MustCompile(c.expected),
+		})
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  server.providers(),
+		Steps:      steps,
+	})
+}
+
+// testAccCheckNewRelicAlertPolicyBundleFillValueOffline verifies the fill
+// value of the first NRQL condition of the bundle on the fake server.
+func testAccCheckNewRelicAlertPolicyBundleFillValueOffline(server *testFakeServer, n string, fillValue float64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conditionID, err := strconv.Atoi(s.RootModule().Resources[n].Primary.Attributes["nrql_condition.0.condition_id"])
+		if err != nil {
+			return err
+		}
+
+		server.mu.Lock()
+		defer server.mu.Unlock()
+
+		signal, _ := server.nrqlConditions[conditionID]["signal"].(testFakeObject)
+		if value, ok := signal["fillValue"]; !ok || value != fillValue {
+			return fmt.Errorf("expected condition %d to have a fill value of %g, got %v", conditionID, fillValue, value)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckNewRelicAlertPolicyBundleConditionsOffline verifies the policy
+// of the bundle has exactly the named NRQL conditions on the fake server.
+func testAccCheckNewRelicAlertPolicyBundleConditionsOffline(server *testFakeServer, n string, names ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		policyID := s.RootModule().Resources[n].Primary.ID
+
+		server.mu.Lock()
+		defer server.mu.Unlock()
+
+		conditionNames := []string{}
+		for _, id := range testFakeSortedIDs(server.nrqlConditions) {
+			condition := server.nrqlConditions[id]
+			if fmt.Sprint(condition["policyId"]) == policyID {
+				conditionNames = append(conditionNames, condition["name"].(string))
+			}
+		}
+
+		sort.Strings(conditionNames)
+
+		if strings.Join(conditionNames, ",") != strings.Join(names, ",") {
+			return fmt.Errorf("expected policy %s to have conditions %v, got %v", policyID, names, conditionNames)
+		}
+
+		return nil
+	}
+}
+
+func testAccNewRelicAlertPolicyBundleConditionConfig(name string, conditionType string, threshold int) string {
+	valueFunction := ""
+	if conditionType == "static" {
+		valueFunction = `value_function = "single_value"`
+	} else {
+		valueFunction = `baseline_direction = "upper_only"`
+	}
+
+	return fmt.Sprintf(`
+  nrql_condition {
+    name = "%s"
+    type = "%s"
+    %s
+    violation_time_limit_seconds = 3600
+
+    nrql {
+      query             = "SELECT count(*) FROM Transaction"
+      evaluation_offset = 3
+    }
+
+    critical {
+      operator              = "above"
+      threshold             = %d
+      threshold_duration    = 300
+      threshold_occurrences = "ALL"
+    }
+  }
+`, name, conditionType, valueFunction, threshold)
+}
+
+// testAccNewRelicAlertPolicyBundleCustomConditionConfig returns a static
+// condition with the given attributes added.
+func testAccNewRelicAlertPolicyBundleCustomConditionConfig(attrs string) string {
+	return fmt.Sprintf(`
+  nrql_condition {
+    name                         = "Error rate"
+    type                         = "static"
+    value_function               = "single_value"
+    violation_time_limit_seconds = 3600
+    %s
+
+    nrql {
+      query             = "SELECT count(*) FROM Transaction"
+      evaluation_offset = 3
+    }
+
+    critical {
+      operator              = "above"
+      threshold             = 5
+      threshold_duration    = 300
+      threshold_occurrences = "ALL"
+    }
+  }
+`, attrs)
+}
+
+func testAccNewRelicAlertPolicyBundleConfigOffline(server *testFakeServer, conditions ...string) string {
+	return server.providerConfig() + fmt.Sprintf(`
+resource "newrelic_alert_channel" "foo" {
+  name = "tf-test-channel"
+  type = "email"
+
+  config {
+    recipients = "terraform-acctest+foo@hashicorp.com"
+  }
+}
+
+resource "newrelic_alert_policy_bundle" "foo" {
+  name        = "tf-test-bundle"
+  channel_ids = [newrelic_alert_channel.foo.id]
+%s
+}
+`, strings.Join(conditions, ""))
+}
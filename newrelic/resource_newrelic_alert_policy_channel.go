@@ -1,6 +1,7 @@
 package newrelic
 
 import (
+	"fmt"
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -13,10 +14,10 @@ func resourceNewRelicAlertPolicyChannel() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceNewRelicAlertPolicyChannelCreate,
 		Read:   resourceNewRelicAlertPolicyChannelRead,
-		// Update: Not currently supported in API
+		Update: resourceNewRelicAlertPolicyChannelUpdate,
 		Delete: resourceNewRelicAlertPolicyChannelDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceNewRelicAlertPolicyChannelImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"policy_id": {
@@ -26,11 +27,10 @@ func resourceNewRelicAlertPolicyChannel() *schema.Resource {
 				Description: "The ID of the policy.",
 			},
 			"channel_ids": {
-				Type:        schema.TypeList,
+				Type:        schema.TypeSet,
 				Required:    true,
-				ForceNew:    true,
 				MinItems:    1,
-				Description: "Array of channel IDs to apply to the specified policy. We recommended sorting channel IDs in ascending order to avoid drift your Terraform state.",
+				Description: "Set of channel IDs to apply to the specified policy. Channels added to or removed from this set are attached to or detached from the policy.",
 				Elem: &schema.Schema{
 					Type: schema.TypeInt,
 				},
@@ -47,8 +47,6 @@ func resourceNewRelicAlertPolicyChannelCreate(d *schema.ResourceData, meta inter
 		return err
 	}
 
-	sortIntegerSlice(policyChannels.ChannelIDs)
-
 	serializedID := serializeIDs(append(
 		[]int{policyChannels.ID},
 		policyChannels.ChannelIDs...,
@@ -71,7 +69,8 @@ func resourceNewRelicAlertPolicyChannelCreate(d *schema.ResourceData, meta inter
 }
 
 func resourceNewRelicAlertPolicyChannelRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ProviderConfig).NewClient
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
 
 	ids, err := parseHashedIDs(d.Id())
 	if err != nil {
@@ -79,24 +78,62 @@ func resourceNewRelicAlertPolicyChannelRead(d *schema.ResourceData, meta interfa
 	}
 
 	policyID := ids[0]
-	parsedChannelIDs := ids[1:]
-
-	sortIntegerSlice(parsedChannelIDs)
 
 	log.Printf("[INFO] Reading New Relic alert policy channel %s", d.Id())
 
-	exists, err := policyChannelsExist(client, policyID, parsedChannelIDs)
-
+	// Channels detached outside of Terraform are attached again on the next
+	// apply. Other channels attached to the policy are left alone.
+	channelIDs, err := findAttachedChannelIDs(client, providerConfig.AccountID, policyID, ids[1:])
 	if err != nil {
 		return err
 	}
 
-	if !exists {
+	if len(channelIDs) == 0 {
 		d.SetId("")
 		return nil
 	}
 
-	return flattenAlertPolicyChannels(d, policyID, parsedChannelIDs)
+	return flattenAlertPolicyChannels(d, policyID, channelIDs)
+}
+
+func resourceNewRelicAlertPolicyChannelUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ProviderConfig).NewClient
+	policyChannels, err := expandAlertPolicyChannels(d)
+
+	if err != nil {
+		return err
+	}
+
+	policyID := policyChannels.ID
+
+	o, n := d.GetChange("channel_ids")
+	addChannelIDs := expandChannelIDs(n.(*schema.Set).Difference(o.(*schema.Set)).List())
+	removeChannelIDs := expandChannelIDs(o.(*schema.Set).Difference(n.(*schema.Set)).List())
+
+	sortIntegerSlice(addChannelIDs)
+	sortIntegerSlice(removeChannelIDs)
+
+	if len(addChannelIDs) > 0 {
+		log.Printf("[INFO] Adding channels %+v to policy %d", addChannelIDs, policyID)
+
+		if _, err := client.Alerts.UpdatePolicyChannels(policyID, addChannelIDs); err != nil {
+			return err
+		}
+	}
+
+	for _, id := range removeChannelIDs {
+		log.Printf("[INFO] Removing channel %d from policy %d", id, policyID)
+
+		if _, err := client.Alerts.DeletePolicyChannel(policyID, id); err != nil {
+			if _, ok := err.(*errors.NotFound); !ok {
+				return err
+			}
+		}
+	}
+
+	d.SetId(serializeIDs(append([]int{policyID}, policyChannels.ChannelIDs...)))
+
+	return resourceNewRelicAlertPolicyChannelRead(d, meta)
 }
 
 func resourceNewRelicAlertPolicyChannelDelete(d *schema.ResourceData, meta interface{}) error {
@@ -112,17 +149,9 @@ func resourceNewRelicAlertPolicyChannelDelete(d *schema.ResourceData, meta inter
 
 	log.Printf("[INFO] Deleting New Relic alert policy channel %s", d.Id())
 
-	exists, err := policyChannelsExist(client, policyID, channelIDs)
-	if err != nil {
-		return err
-	}
-
-	if exists {
-		for _, id := range channelIDs {
-			if _, err := client.Alerts.DeletePolicyChannel(policyID, id); err != nil {
-				if _, ok := err.(*errors.NotFound); ok {
-					return nil
-				}
+	for _, id := range channelIDs {
+		if _, err := client.Alerts.DeletePolicyChannel(policyID, id); err != nil {
+			if _, ok := err.(*errors.NotFound); !ok {
 				return err
 			}
 		}
@@ -131,6 +160,32 @@ func resourceNewRelicAlertPolicyChannelDelete(d *schema.ResourceData, meta inter
 	return nil
 }
 
+// resourceNewRelicAlertPolicyChannelImport accepts the ID of a policy alone,
+// in which case every channel attached to the policy is imported.
+func resourceNewRelicAlertPolicyChannelImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	ids, err := parseHashedIDs(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) > 1 {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	channelIDs, err := findPolicyChannelIDs(meta.(*ProviderConfig).NewClient, ids[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(channelIDs) == 0 {
+		return nil, fmt.Errorf("no channels are attached to alert policy %d", ids[0])
+	}
+
+	d.SetId(serializeIDs(append(ids, channelIDs...)))
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func policyChannelsExist(
 	client *newrelic.NewRelic,
 	policyID int,
@@ -0,0 +1,210 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccNewRelicAlertPolicyChannel_Offline(t *testing.T) {
+	resourceName := "newrelic_alert_policy_channel.foo"
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicAlertPolicyChannelConfigOffline(server, "newrelic_alert_channel.foo.id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "policy_id", "newrelic_alert_policy.foo", "id"),
+					resource.TestCheckResourceAttr(resourceName, "channel_ids.#", "1"),
+					testAccCheckNewRelicAlertPolicyChannelLinksOffline(server, resourceName, "newrelic_alert_channel.foo"),
+				),
+			},
+			// Test: Update adds the new channel only
+			{
+				Config: testAccNewRelicAlertPolicyChannelConfigOffline(server, "newrelic_alert_channel.bar.id", "newrelic_alert_channel.foo.id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "channel_ids.#", "2"),
+					testAccCheckNewRelicAlertPolicyChannelLinksOffline(server, resourceName, "newrelic_alert_channel.foo", "newrelic_alert_channel.bar"),
+				),
+			},
+			// Test: The order of the channels doesn't matter
+			{
+				Config:   testAccNewRelicAlertPolicyChannelConfigOffline(server, "newrelic_alert_channel.foo.id", "newrelic_alert_channel.bar.id"),
+				PlanOnly: true,
+			},
+			// Test: Import by policy ID
+			{
+				Config:            testAccNewRelicAlertPolicyChannelConfigOffline(server, "newrelic_alert_channel.foo.id", "newrelic_alert_channel.bar.id"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources["newrelic_alert_policy.foo"].Primary.ID, nil
+				},
+			},
+			// Test: Import by policy and channel IDs
+			{
+				Config:            testAccNewRelicAlertPolicyChannelConfigOffline(server, "newrelic_alert_channel.foo.id", "newrelic_alert_channel.bar.id"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Test: Update removes the old channel only
+			{
+				Config: testAccNewRelicAlertPolicyChannelConfigOffline(server, "newrelic_alert_channel.bar.id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "channel_ids.#", "1"),
+					testAccCheckNewRelicAlertPolicyChannelLinksOffline(server, resourceName, "newrelic_alert_channel.bar"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicAlertPolicyChannel_RefreshOffline(t *testing.T) {
+	resourceName := "newrelic_alert_policy.foo"
+	server := newTestFakeServer(t)
+	channelID := server.addChannel("tf-test-channel")
+	config := server.providerConfig() + fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-policy"
+}
+
+resource "newrelic_alert_policy_channel" "foo" {
+  policy_id   = newrelic_alert_policy.foo.id
+  channel_ids = [%d]
+}
+`, channelID)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  server.providers(),
+		CheckDestroy: func(s *terraform.State) error {
+			server.mu.Lock()
+			delete(server.channels, channelID)
+			server.mu.Unlock()
+
+			return server.checkDestroy(s)
+		},
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: config,
+				Check:  testAccCheckNewRelicAlertPolicyChannelIDsOffline(server, resourceName, channelID),
+			},
+			// Test: Refresh only reads the channels of the resource
+			{
+				PreConfig: func() {
+					server.mu.Lock()
+					server.channelListings = 0
+					server.mu.Unlock()
+				},
+				Config: config,
+				Check: func(s *terraform.State) error {
+					server.mu.Lock()
+					defer server.mu.Unlock()
+
+					if server.channelListings > 0 {
+						return fmt.Errorf("expected no listing of the alert channels, got %d", server.channelListings)
+					}
+					return nil
+				},
+			},
+			// Test: A channel detached outside of Terraform is attached again
+			{
+				PreConfig: func() {
+					server.mu.Lock()
+					for policyID := range server.policies {
+						server.linkChannel(channelID, policyID, false)
+					}
+					server.mu.Unlock()
+				},
+				Config: config,
+				Check:  testAccCheckNewRelicAlertPolicyChannelIDsOffline(server, resourceName, channelID),
+			},
+		},
+	})
+}
+
+// testAccCheckNewRelicAlertPolicyChannelLinksOffline checks that the channels
+// attached to the policy on the fake server are exactly the given channels.
+func testAccCheckNewRelicAlertPolicyChannelLinksOffline(server *testFakeServer, n string, channels ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		policyID, err := strconv.Atoi(rs.Primary.Attributes["policy_id"])
+		if err != nil {
+			return err
+		}
+
+		expected := []int{}
+		for _, c := range channels {
+			id, err := strconv.Atoi(s.RootModule().Resources[c].Primary.ID)
+			if err != nil {
+				return err
+			}
+
+			expected = append(expected, id)
+		}
+		sortIntegerSlice(expected)
+
+		server.mu.Lock()
+		actual := server.policyChannelIDs(policyID)
+		server.mu.Unlock()
+
+		if fmt.Sprint(actual) != fmt.Sprint(expected) {
+			return fmt.Errorf("expected channels %v to be attached to policy %d, got %v", expected, policyID, actual)
+		}
+
+		if rs.Primary.ID != serializeIDs(append([]int{policyID}, expected...)) {
+			return fmt.Errorf("unexpected ID %s", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccNewRelicAlertPolicyChannelConfigOffline(server *testFakeServer, channelIDs ...string) string {
+	return server.providerConfig() + fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-policy"
+}
+
+resource "newrelic_alert_channel" "foo" {
+  name = "tf-test-foo"
+  type = "email"
+
+  config {
+    recipients = "terraform-acctest+foo@hashicorp.com"
+  }
+}
+
+resource "newrelic_alert_channel" "bar" {
+  name = "tf-test-bar"
+  type = "email"
+
+  config {
+    recipients = "terraform-acctest+bar@hashicorp.com"
+  }
+}
+
+resource "newrelic_alert_policy_channel" "foo" {
+  policy_id   = newrelic_alert_policy.foo.id
+  channel_ids = [%s]
+}
+`, strings.Join(channelIDs, ", "))
+}
@@ -3,10 +3,15 @@
 package newrelic
 
 import (
+	"fmt"
 	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 )
 
 func TestAccNewRelicAlertPolicy_ErrorThrownWhenNameEmpty(t *testing.T) {
@@ -25,3 +30,321 @@ func TestAccNewRelicAlertPolicy_ErrorThrownWhenNameEmpty(t *testing.T) {
 		},
 	})
 }
+
+func TestAccNewRelicAlertPolicy_Offline(t *testing.T) {
+	resourceName := "newrelic_alert_policy.foo"
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicAlertPolicyConfigOffline(server, "tf-test-policy", "PER_POLICY"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test-policy"),
+					resource.TestCheckResourceAttr(resourceName, "incident_preference", "PER_POLICY"),
+					resource.TestCheckResourceAttr(resourceName, "account_id", fmt.Sprint(testFakeAccountID)),
+				),
+			},
+			// Test: Update
+			{
+				Config: testAccNewRelicAlertPolicyConfigOffline(server, "tf-test-policy-updated", "PER_CONDITION"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test-policy-updated"),
+					resource.TestCheckResourceAttr(resourceName, "incident_preference", "PER_CONDITION"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            testAccNewRelicAlertPolicyConfigOffline(server, "tf-test-policy-updated", "PER_CONDITION"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return fmt.Sprintf("%s:%d", s.RootModule().Resources[resourceName].Primary.ID, testFakeAccountID), nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccNewRelicAlertPolicy_DefaultTagsOffline(t *testing.T) {
+	resourceName := "newrelic_alert_policy.foo"
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: Apply the provider's default tags
+			{
+				Config: testAccNewRelicAlertPolicyConfigOffline(server, "tf-test-policy", "PER_POLICY", `
+  default_tags {
+    tags = {
+      team = "platform"
+    }
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "default_tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "default_tags.team", "platform"),
+					testAccCheckNewRelicAlertPolicyTagsOffline(server, resourceName, "platform"),
+				),
+			},
+			// Test: Change the default tags
+			{
+				Config: testAccNewRelicAlertPolicyConfigOffline(server, "tf-test-policy", "PER_POLICY", `
+  default_tags {
+    tags = {
+      team = "sre"
+    }
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "default_tags.team", "sre"),
+					testAccCheckNewRelicAlertPolicyTagsOffline(server, resourceName, "sre"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckNewRelicAlertPolicyTagsOffline verifies the team tag of a policy
+// entity on the fake server.
+func testAccCheckNewRelicAlertPolicyTagsOffline(server *testFakeServer, n string, team string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		guid := string(alertPolicyGUID(testFakeAccountID, s.RootModule().Resources[n].Primary.ID))
+
+		server.mu.Lock()
+		defer server.mu.Unlock()
+
+		if values := server.tags[guid]["team"]; len(values) != 1 || values[0] != team {
+			return fmt.Errorf("expected the team tag %s on policy %s, got %v", team, guid, values)
+		}
+
+		return nil
+	}
+}
+
+func testAccNewRelicAlertPolicyConfigOffline(server *testFakeServer, name string, incidentPreference string, settings ...string) string {
+	return server.providerConfig(settings...) + fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+  name                = "%s"
+  incident_preference = "%s"
+}
+`, name, incidentPreference)
+}
+
+func TestAccNewRelicAlertPolicy_ChannelsOffline(t *testing.T) {
+	resourceName := "newrelic_alert_policy.foo"
+	server := newTestFakeServer(t)
+	var policyID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicAlertPolicyWithChannelsConfigOffline(server, "newrelic_alert_channel.foo.id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "channel_ids.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "channel_ids.0", "newrelic_alert_channel.foo", "id"),
+					testAccCheckNewRelicAlertPolicyChannelsOffline(server, resourceName, "newrelic_alert_channel.foo"),
+					func(s *terraform.State) error {
+						policyID = s.RootModule().Resources[resourceName].Primary.ID
+						return nil
+					},
+				),
+			},
+			// Test: Update in place
+			{
+				Config: testAccNewRelicAlertPolicyWithChannelsConfigOffline(server, "newrelic_alert_channel.foo.id", "newrelic_alert_channel.bar.id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "channel_ids.#", "2"),
+					testAccCheckNewRelicAlertPolicyChannelsOffline(server, resourceName, "newrelic_alert_channel.foo", "newrelic_alert_channel.bar"),
+				),
+			},
+			{
+				Config: testAccNewRelicAlertPolicyWithChannelsConfigOffline(server, "newrelic_alert_channel.bar.id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "channel_ids.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "channel_ids.0", "newrelic_alert_channel.bar", "id"),
+					testAccCheckNewRelicAlertPolicyChannelsOffline(server, resourceName, "newrelic_alert_channel.bar"),
+					func(s *terraform.State) error {
+						if id := s.RootModule().Resources[resourceName].Primary.ID; id != policyID {
+							return fmt.Errorf("expected policy %s to be updated in place, got %s", policyID, id)
+						}
+						return nil
+					},
+				),
+			},
+			// Test: Import
+			{
+				Config:            testAccNewRelicAlertPolicyWithChannelsConfigOffline(server, "newrelic_alert_channel.bar.id"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicAlertPolicy_UnknownChannelOffline(t *testing.T) {
+	server := newTestFakeServer(t)
+	expectedErrorMsg, _ := regexp.Compile(`alert channel 999999 not found`)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNewRelicAlertPolicyWithChannelsConfigOffline(server, "999999"),
+				ExpectError: expectedErrorMsg,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicAlertPolicy_RefreshChannelsOffline(t *testing.T) {
+	resourceName := "newrelic_alert_policy.foo"
+	server := newTestFakeServer(t)
+	channelID := server.addChannel("tf-test-channel")
+	config := server.providerConfig() + fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+  name        = "tf-test-policy"
+  channel_ids = [%d]
+}
+`, channelID)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  server.providers(),
+		CheckDestroy: func(s *terraform.State) error {
+			server.mu.Lock()
+			delete(server.channels, channelID)
+			server.mu.Unlock()
+
+			return server.checkDestroy(s)
+		},
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: config,
+				Check:  testAccCheckNewRelicAlertPolicyChannelIDsOffline(server, resourceName, channelID),
+			},
+			// Test: Refresh only reads the channels in channel_ids
+			{
+				PreConfig: func() {
+					server.mu.Lock()
+					server.channelListings = 0
+					server.mu.Unlock()
+				},
+				Config: config,
+				Check: func(s *terraform.State) error {
+					server.mu.Lock()
+					defer server.mu.Unlock()
+
+					if server.channelListings > 0 {
+						return fmt.Errorf("expected no listing of the alert channels, got %d", server.channelListings)
+					}
+					return nil
+				},
+			},
+			// Test: A channel detached outside of Terraform is attached again
+			{
+				PreConfig: func() {
+					server.mu.Lock()
+					for policyID := range server.policies {
+						server.linkChannel(channelID, policyID, false)
+					}
+					server.mu.Unlock()
+				},
+				Config: config,
+				Check:  testAccCheckNewRelicAlertPolicyChannelIDsOffline(server, resourceName, channelID),
+			},
+		},
+	})
+}
+
+// testAccCheckNewRelicAlertPolicyChannelIDsOffline verifies the channels
+// attached to the policy on the fake server.
+func testAccCheckNewRelicAlertPolicyChannelIDsOffline(server *testFakeServer, n string, expected ...int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		policyID, err := strconv.Atoi(s.RootModule().Resources[n].Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		server.mu.Lock()
+		defer server.mu.Unlock()
+
+		if ids := server.policyChannelIDs(policyID); fmt.Sprint(ids) != fmt.Sprint(expected) {
+			return fmt.Errorf("expected policy %d to have channels %v attached, got %v", policyID, expected, ids)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckNewRelicAlertPolicyChannelsOffline(server *testFakeServer, n string, channels ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		policyID, err := strconv.Atoi(s.RootModule().Resources[n].Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		expected := []int{}
+		for _, c := range channels {
+			id, err := strconv.Atoi(s.RootModule().Resources[c].Primary.ID)
+			if err != nil {
+				return err
+			}
+
+			expected = append(expected, id)
+		}
+		sort.Ints(expected)
+
+		server.mu.Lock()
+		defer server.mu.Unlock()
+
+		if ids := server.policyChannelIDs(policyID); fmt.Sprint(ids) != fmt.Sprint(expected) {
+			return fmt.Errorf("expected policy %d to have channels %v attached, got %v", policyID, expected, ids)
+		}
+
+		return nil
+	}
+}
+
+func testAccNewRelicAlertPolicyWithChannelsConfigOffline(server *testFakeServer, channelIDs ...string) string {
+	return server.providerConfig() + fmt.Sprintf(`
+resource "newrelic_alert_channel" "foo" {
+  name = "tf-test-channel-foo"
+  type = "email"
+
+  config {
+    recipients = "terraform-acctest+foo@hashicorp.com"
+  }
+}
+
+resource "newrelic_alert_channel" "bar" {
+  name = "tf-test-channel-bar"
+  type = "email"
+
+  config {
+    recipients = "terraform-acctest+bar@hashicorp.com"
+  }
+
+  depends_on = [newrelic_alert_channel.foo]
+}
+
+resource "newrelic_alert_policy" "foo" {
+  name        = "tf-test-policy"
+  channel_ids = [%s]
+}
+`, strings.Join(channelIDs, ", "))
+}
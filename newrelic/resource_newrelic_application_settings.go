@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/newrelic/newrelic-client-go/pkg/apm"
+	"github.com/newrelic/newrelic-client-go/pkg/entities"
 )
 
 func resourceNewRelicApplicationSettings() *schema.Resource {
@@ -19,6 +20,7 @@ func resourceNewRelicApplicationSettings() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: customizeDiffDefaultTags,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -36,6 +38,7 @@ func resourceNewRelicApplicationSettings() *schema.Resource {
 				Type:     schema.TypeBool,
 				Required: true,
 			},
+			"default_tags": defaultTagsSchema(),
 		},
 	}
 }
@@ -83,7 +86,11 @@ func resourceNewRelicApplicationSettingsRead(d *schema.ResourceData, meta interf
 
 	log.Printf("[INFO] Read found New Relic application %+v\n\n\n", app)
 
-	return flattenApplication(app, d)
+	if err := flattenApplication(app, d); err != nil {
+		return err
+	}
+
+	return readDefaultTags(d, meta, applicationGUID(meta, app.ID))
 }
 
 func resourceNewRelicApplicationSettingsUpdate(d *schema.ResourceData, meta interface{}) error {
@@ -103,12 +110,27 @@ func resourceNewRelicApplicationSettingsUpdate(d *schema.ResourceData, meta inte
 		return err
 	}
 
+	if err := updateDefaultTags(d, meta, applicationGUID(meta, app.ID)); err != nil {
+		return err
+	}
+
 	time.Sleep(2 * time.Second)
 
 	return flattenApplication(app, d)
 }
 
 func resourceNewRelicApplicationSettingsDelete(d *schema.ResourceData, meta interface{}) error {
-	// You can not delete application settings
-	return nil
+	// You can not delete application settings, the application keeps them. The
+	// default tags are removed, as they are no longer managed.
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+
+	return deleteDefaultTags(d, meta, applicationGUID(meta, id))
+}
+
+// applicationGUID returns the entity GUID of an APM application.
+func applicationGUID(meta interface{}, applicationID int) entities.EntityGUID {
+	return entityGUID(meta.(*ProviderConfig).AccountID, "APM", "APPLICATION", strconv.Itoa(applicationID))
 }
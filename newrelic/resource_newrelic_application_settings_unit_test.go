@@ -0,0 +1,48 @@
+// +build unit
+
+package newrelic
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceNewRelicApplicationSettingsDelete_RemovesDefaultTags(t *testing.T) {
+	server := newTestFakeServer(t)
+
+	cfg := Config{
+		PersonalAPIKey:  "NRAK-TEST",
+		Region:          "US",
+		NerdGraphAPIURL: server.URL + "/graphql",
+		userAgent:       "terraform-provider-newrelic/test",
+	}
+
+	client, err := cfg.Client()
+	require.NoError(t, err)
+
+	meta := &ProviderConfig{
+		NewClient:      client,
+		DefaultTags:    map[string]string{"team": "platform"},
+		AccountID:      testFakeAccountID,
+		PersonalAPIKey: "NRAK-TEST",
+	}
+
+	guid := string(applicationGUID(meta, 42))
+	server.tags[guid] = map[string][]string{
+		"team":  {"platform"},
+		"owner": {"payments"},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceNewRelicApplicationSettings().Schema, map[string]interface{}{
+		"name": "tf-test-app",
+	})
+	d.SetId("42")
+	require.NoError(t, d.Set("default_tags", map[string]interface{}{"team": "platform"}))
+
+	require.NoError(t, resourceNewRelicApplicationSettingsDelete(d, meta))
+
+	assert.Equal(t, map[string][]string{"owner": {"payments"}}, server.tags[guid], "only the default tags are removed")
+}
@@ -91,15 +91,8 @@ func resourceNewRelicEntityTagsCreate(d *schema.ResourceData, meta interface{})
 			return resource.NonRetryableError(fmt.Errorf("error retrieving entity tags for guid %s: %s", d.Id(), err))
 		}
 
-		for _, t := range tags {
-			var tag *entities.Tag
-			if tag = getTag(currentTags, t.Key); tag == nil {
-				return resource.RetryableError(fmt.Errorf("expected entity tag %s to have been updated but was not found", t.Key))
-			}
-
-			if ok := tagValuesExist(tag, t.Values); !ok {
-				return resource.RetryableError(fmt.Errorf("expected entity tag values %s to have been updated for tag %s but were not found", t.Values, t.Key))
-			}
+		if err := checkEntityTags(currentTags, tags); err != nil {
+			return resource.RetryableError(err)
 		}
 
 		return resource.NonRetryableError(resourceNewRelicEntityTagsRead(d, meta))
@@ -155,15 +148,8 @@ func resourceNewRelicEntityTagsUpdate(d *schema.ResourceData, meta interface{})
 			return resource.NonRetryableError(fmt.Errorf("error retrieving entity tags for guid %s: %s", d.Id(), err))
 		}
 
-		for _, t := range tags {
-			var tag *entities.Tag
-			if tag = getTag(currentTags, t.Key); tag == nil {
-				return resource.RetryableError(fmt.Errorf("expected entity tag %s to have been updated but was not found", t.Key))
-			}
-
-			if ok := tagValuesExist(tag, t.Values); !ok {
-				return resource.RetryableError(fmt.Errorf("expected entity tag values %s to have been created for tag %s but were not found", t.Values, t.Key))
-			}
+		if err := checkEntityTags(currentTags, tags); err != nil {
+			return resource.RetryableError(err)
 		}
 
 		return resource.NonRetryableError(resourceNewRelicEntityTagsRead(d, meta))
@@ -242,6 +228,23 @@ func flattenEntityTags(d *schema.ResourceData, tags []*entities.Tag) error {
 	return nil
 }
 
+// checkEntityTags returns an error for the first of the given tags whose key
+// or values are missing from an entity's current tags.
+func checkEntityTags(currentTags []*entities.Tag, tags []entities.Tag) error {
+	for _, t := range tags {
+		var tag *entities.Tag
+		if tag = getTag(currentTags, t.Key); tag == nil {
+			return fmt.Errorf("expected entity tag %s to have been updated but was not found", t.Key)
+		}
+
+		if ok := tagValuesExist(tag, t.Values); !ok {
+			return fmt.Errorf("expected entity tag values %s to have been updated for tag %s but were not found", t.Values, t.Key)
+		}
+	}
+
+	return nil
+}
+
 func getTagKeys(tags []entities.Tag) []string {
 	tagKeys := []string{}
 
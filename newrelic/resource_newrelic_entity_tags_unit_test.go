@@ -0,0 +1,59 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccNewRelicEntityTags_Offline(t *testing.T) {
+	resourceName := "newrelic_entity_tags.foo"
+	server := newTestFakeServer(t)
+	guid := string(entityGUID(testFakeAccountID, "APM", "APPLICATION", "12345"))
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicEntityTagsConfigOffline(server, guid, "test_value"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "guid", guid),
+					resource.TestCheckResourceAttr(resourceName, "tag.#", "1"),
+				),
+			},
+			// Test: Update
+			{
+				Config: testAccNewRelicEntityTagsConfigOffline(server, guid, "test_value_updated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tag.#", "1"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            testAccNewRelicEntityTagsConfigOffline(server, guid, "test_value_updated"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNewRelicEntityTagsConfigOffline(server *testFakeServer, guid string, value string) string {
+	return server.providerConfig() + fmt.Sprintf(`
+resource "newrelic_entity_tags" "foo" {
+  guid = "%s"
+
+  tag {
+    key    = "test_key"
+    values = ["%s"]
+  }
+}
+`, guid, value)
+}
@@ -0,0 +1,72 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccNewRelicInfraAlertCondition_Offline(t *testing.T) {
+	resourceName := "newrelic_infra_alert_condition.foo"
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicInfraAlertConditionConfigOffline(server, "tf-test-condition", 10),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test-condition"),
+					resource.TestCheckResourceAttr(resourceName, "critical.0.value", "10"),
+				),
+			},
+			// Test: Update
+			{
+				Config: testAccNewRelicInfraAlertConditionConfigOffline(server, "tf-test-condition-updated", 20),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test-condition-updated"),
+					resource.TestCheckResourceAttr(resourceName, "critical.0.value", "20"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            testAccNewRelicInfraAlertConditionConfigOffline(server, "tf-test-condition-updated", 20),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNewRelicInfraAlertConditionConfigOffline(server *testFakeServer, name string, threshold int) string {
+	return server.providerConfig() + fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-policy"
+}
+
+resource "newrelic_infra_alert_condition" "foo" {
+  policy_id = newrelic_alert_policy.foo.id
+
+  name        = "%s"
+  runbook_url = "https://foo.example.com"
+  type        = "infra_metric"
+  event       = "StorageSample"
+  select      = "diskFreePercent"
+  comparison  = "below"
+  description = "test description"
+
+  critical {
+    duration      = 10
+    value         = %d
+    time_function = "any"
+  }
+}
+`, name, threshold)
+}
@@ -0,0 +1,151 @@
+package newrelic
+
+import (
+	"errors"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	nrErrors "github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+func resourceNewRelicNotificationChannel() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicNotificationChannelCreate,
+		Read:   resourceNewRelicNotificationChannelRead,
+		Update: resourceNewRelicNotificationChannelUpdate,
+		Delete: resourceNewRelicNotificationChannelDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The New Relic account ID to operate on.",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The name of the channel.",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(notificationChannelTypes, false),
+				Description:  "The type of the channel.",
+			},
+			"destination_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The ID of the destination the channel sends notifications to.",
+			},
+			"product": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(notificationChannelProducts, false),
+				Description:  "The New Relic product the channel is used by. Workflows use channels of the IINT product.",
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the channel is active.",
+			},
+			"property": notificationPropertySchema("The settings of the channel, e.g. the payload of a webhook or the subject of an email."),
+		},
+	}
+}
+
+func resourceNewRelicNotificationChannelCreate(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return errors.New("err: NerdGraph support not present, but required for Create")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+	input := expandNotificationChannelInput(d)
+
+	log.Printf("[INFO] Creating New Relic notification channel %s", input.Name)
+
+	channel, err := createNotificationChannel(client, accountID, input)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(channel.ID)
+
+	return resourceNewRelicNotificationChannelRead(d, meta)
+}
+
+func resourceNewRelicNotificationChannelRead(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return errors.New("err: NerdGraph support not present, but required for Read")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Reading New Relic notification channel %s", d.Id())
+
+	channel, err := getNotificationChannel(client, accountID, d.Id())
+	if err != nil {
+		if _, ok := err.(*nrErrors.NotFound); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if err := d.Set("account_id", accountID); err != nil {
+		return err
+	}
+
+	return flattenNotificationChannel(channel, d)
+}
+
+func resourceNewRelicNotificationChannelUpdate(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return errors.New("err: NerdGraph support not present, but required for Update")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Updating New Relic notification channel %s", d.Id())
+
+	if _, err := updateNotificationChannel(client, accountID, d.Id(), expandNotificationChannelInput(d)); err != nil {
+		return err
+	}
+
+	return resourceNewRelicNotificationChannelRead(d, meta)
+}
+
+func resourceNewRelicNotificationChannelDelete(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return errors.New("err: NerdGraph support not present, but required for Delete")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Deleting New Relic notification channel %s", d.Id())
+
+	return deleteNotificationChannel(client, accountID, d.Id())
+}
@@ -0,0 +1,101 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccNewRelicNotificationChannel_Offline(t *testing.T) {
+	resourceName := "newrelic_notification_channel.foo"
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicNotificationChannelConfigOffline(server, "tf-test-channel", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test-channel"),
+					resource.TestCheckResourceAttr(resourceName, "type", "WEBHOOK"),
+					resource.TestCheckResourceAttr(resourceName, "product", "IINT"),
+					resource.TestCheckResourceAttr(resourceName, "active", "true"),
+					resource.TestCheckResourceAttrPair(resourceName, "destination_id", "newrelic_notification_destination.foo", "id"),
+				),
+			},
+			// Test: Update
+			{
+				Config: testAccNewRelicNotificationChannelConfigOffline(server, "tf-test-channel-updated", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test-channel-updated"),
+					resource.TestCheckResourceAttr(resourceName, "active", "false"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            testAccNewRelicNotificationChannelConfigOffline(server, "tf-test-channel-updated", false),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicNotificationChannel_UnknownDestinationOffline(t *testing.T) {
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: server.providerConfig() + `
+resource "newrelic_notification_channel" "foo" {
+  name           = "tf-test-channel"
+  type           = "WEBHOOK"
+  destination_id = "00000000-0000-4000-8000-000000000000"
+  product        = "IINT"
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid channel, destinationId: destination not found"),
+			},
+		},
+	})
+}
+
+func testAccNewRelicNotificationChannelConfigOffline(server *testFakeServer, name string, active bool) string {
+	return server.providerConfig() + fmt.Sprintf(`
+resource "newrelic_notification_destination" "foo" {
+  name = "tf-test-destination"
+  type = "WEBHOOK"
+
+  property {
+    key   = "url"
+    value = "https://example.com/hook"
+  }
+}
+
+resource "newrelic_notification_channel" "foo" {
+  name           = "%s"
+  type           = "WEBHOOK"
+  destination_id = newrelic_notification_destination.foo.id
+  product        = "IINT"
+  active         = %t
+
+  property {
+    key   = "payload"
+    value = "{\"issue\": \"{{ issueTitle }}\"}"
+    label = "Payload"
+  }
+}
+`, name, active)
+}
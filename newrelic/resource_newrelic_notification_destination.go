@@ -0,0 +1,216 @@
+package newrelic
+
+import (
+	"errors"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	nrErrors "github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+// notificationPropertySchema is the schema of the key/value settings of
+// notification destinations and channels.
+func notificationPropertySchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"key": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.NoZeroValues,
+					Description:  "The key of the property.",
+				},
+				"value": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The value of the property.",
+				},
+				"label": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The label of the property.",
+				},
+				"display_value": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The value of the property as displayed in New Relic.",
+				},
+			},
+		},
+	}
+}
+
+func resourceNewRelicNotificationDestination() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicNotificationDestinationCreate,
+		Read:   resourceNewRelicNotificationDestinationRead,
+		Update: resourceNewRelicNotificationDestinationUpdate,
+		Delete: resourceNewRelicNotificationDestinationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The New Relic account ID to operate on.",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The name of the destination.",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(notificationDestinationTypes, false),
+				Description:  "The type of the destination.",
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the destination is active.",
+			},
+			"property": notificationPropertySchema("The settings of the destination, e.g. the URL of a webhook or the address of an email destination."),
+			"auth_basic": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"auth_token"},
+				Description:   "Basic authentication for the destination.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"user": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The user name.",
+						},
+						"password": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "The password.",
+						},
+					},
+				},
+			},
+			"auth_token": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"auth_basic"},
+				Description:   "Token authentication for the destination.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"prefix": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The prefix of the token, e.g. Bearer.",
+						},
+						"token": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "The token.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceNewRelicNotificationDestinationCreate(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return errors.New("err: NerdGraph support not present, but required for Create")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+	input := expandNotificationDestinationInput(d)
+
+	log.Printf("[INFO] Creating New Relic notification destination %s", input.Name)
+
+	destination, err := createNotificationDestination(client, accountID, input)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(destination.ID)
+
+	return resourceNewRelicNotificationDestinationRead(d, meta)
+}
+
+func resourceNewRelicNotificationDestinationRead(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return errors.New("err: NerdGraph support not present, but required for Read")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Reading New Relic notification destination %s", d.Id())
+
+	destination, err := getNotificationDestination(client, accountID, d.Id())
+	if err != nil {
+		if _, ok := err.(*nrErrors.NotFound); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if err := d.Set("account_id", accountID); err != nil {
+		return err
+	}
+
+	return flattenNotificationDestination(destination, d)
+}
+
+func resourceNewRelicNotificationDestinationUpdate(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return errors.New("err: NerdGraph support not present, but required for Update")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Updating New Relic notification destination %s", d.Id())
+
+	if _, err := updateNotificationDestination(client, accountID, d.Id(), expandNotificationDestinationInput(d)); err != nil {
+		return err
+	}
+
+	return resourceNewRelicNotificationDestinationRead(d, meta)
+}
+
+func resourceNewRelicNotificationDestinationDelete(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return errors.New("err: NerdGraph support not present, but required for Delete")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Deleting New Relic notification destination %s", d.Id())
+
+	return deleteNotificationDestination(client, accountID, d.Id())
+}
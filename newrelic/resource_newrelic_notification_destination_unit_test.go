@@ -0,0 +1,71 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccNewRelicNotificationDestination_Offline(t *testing.T) {
+	resourceName := "newrelic_notification_destination.foo"
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicNotificationDestinationConfigOffline(server, "tf-test-destination", "https://example.com/hook"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test-destination"),
+					resource.TestCheckResourceAttr(resourceName, "type", "WEBHOOK"),
+					resource.TestCheckResourceAttr(resourceName, "active", "true"),
+					resource.TestCheckResourceAttr(resourceName, "property.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "auth_basic.0.user", "tf-test"),
+					resource.TestCheckResourceAttr(resourceName, "auth_basic.0.password", "secret"),
+					resource.TestCheckResourceAttr(resourceName, "account_id", fmt.Sprint(testFakeAccountID)),
+				),
+			},
+			// Test: Update
+			{
+				Config: testAccNewRelicNotificationDestinationConfigOffline(server, "tf-test-destination-updated", "https://example.com/updated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test-destination-updated"),
+					resource.TestCheckResourceAttr(resourceName, "property.#", "1"),
+				),
+			},
+			// Test: Import
+			{
+				Config:                  testAccNewRelicNotificationDestinationConfigOffline(server, "tf-test-destination-updated", "https://example.com/updated"),
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"auth_basic.0.password"},
+			},
+		},
+	})
+}
+
+func testAccNewRelicNotificationDestinationConfigOffline(server *testFakeServer, name string, url string) string {
+	return server.providerConfig() + fmt.Sprintf(`
+resource "newrelic_notification_destination" "foo" {
+  name = "%s"
+  type = "WEBHOOK"
+
+  property {
+    key   = "url"
+    value = "%s"
+  }
+
+  auth_basic {
+    user     = "tf-test"
+    password = "secret"
+  }
+}
+`, name, url)
+}
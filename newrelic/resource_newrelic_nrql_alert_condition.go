@@ -8,7 +8,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	"github.com/newrelic/newrelic-client-go/pkg/alerts"
 	"github.com/newrelic/newrelic-client-go/pkg/errors"
 )
 
@@ -70,27 +69,10 @@ func termSchema() *schema.Resource {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Description: "The duration, in seconds, that the threshold must violate in order to create a violation. Value must be a multiple of the 'aggregation_window' (which has a default of 60 seconds). Value must be within 120-3600 seconds for baseline and outlier conditions, within 120-7200 seconds for static conditions with the sum value function, and within 60-7200 seconds for static conditions with the single_value value function.",
-				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
-					v := val.(int)
-					minVal := 60
-					maxVal := 7200
-
-					// Value must be a factor of 60.
-					if v%60 != 0 {
-						errs = append(errs, fmt.Errorf("%q must be a factor of %d, got: %d", key, minVal, v))
-					}
-
-					// This validation is a top-level validation check.
-					// Static conditions with a single_value value function must be within range [60, 7200].
-					// Static conditions with a sum value function must be within range [120, 7200].
-					// Baseline conditions must be within range [120, 3600].
-					// Outlier conditions must be within range [120, 3600].
-					if v < minVal || v > maxVal {
-						errs = append(errs, fmt.Errorf("%q must be between %d and %d inclusive, got: %d", key, minVal, maxVal, v))
-					}
-
-					return
-				},
+				// This validation is a top-level validation check. The ranges for each
+				// condition type, and the multiple of the aggregation window, are
+				// checked by validateNrqlConditionTerms.
+				ValidateFunc: validation.IntBetween(60, 7200),
 			},
 		},
 	}
@@ -121,6 +103,107 @@ func resourceNewRelicNrqlAlertCondition() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceImportStateWithMetadata(2, "type"),
 		},
+		CustomizeDiff: func(d *schema.ResourceDiff, meta interface{}) error {
+			return validateNrqlCondition(d)
+		},
+		Schema:        nrqlAlertConditionSchema(),
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceNewRelicNrqlAlertConditionV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: migrateStateNewRelicNrqlAlertConditionV0toV1,
+				Version: 0,
+			},
+		},
+	}
+}
+
+// resourceNewRelicNrqlAlertConditionV0 is the schema of version 0, before the
+// state upgrade off the deprecated attributes. It is a copy of the schema at
+// the time, so that later changes to the schema don't change how the states of
+// version 0 are read.
+func resourceNewRelicNrqlAlertConditionV0() *schema.Resource {
+	// termV0 is the schema of a term, with the priority of the deprecated
+	// term blocks when priority is set.
+	termV0 := func(priority bool) *schema.Resource {
+		term := &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				// Maps to `thresholdDuration` in NerdGraph and values are in seconds, not minutes.
+				// Validation is different in NerdGraph - Value must be within 120-3600 seconds (2-60 minutes) and a multiple of 60 for BASELINE conditions.
+				// Convert to seconds when using NerdGraph
+				"duration": {
+					Deprecated:   "use `threshold_duration` attribute instead",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Description:  "In minutes, must be in the range of 1 to 120 (inclusive).",
+					ValidateFunc: validation.IntBetween(1, 120),
+				},
+				// Value must be uppercase when using NerdGraph
+				"operator": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "equals",
+					Description:  "One of (above, below, equals). Defaults to 'equals'.",
+					ValidateFunc: validation.StringInSlice([]string{"above", "below", "equals"}, true),
+					DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+						return strings.EqualFold(old, new)
+					},
+				},
+				"threshold": {
+					Type:         schema.TypeFloat,
+					Required:     true,
+					Description:  "Must be 0 or greater. For baseline conditions must be in range [1, 1000].",
+					ValidateFunc: float64Gte(0.0),
+				},
+				// Does not exist in NerdGraph. Equivalent to `threshold_occurrences`,
+				// but with different wording.
+				"time_function": {
+					Deprecated:   "use `threshold_occurrences` attribute instead",
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "Valid values are: 'all' or 'any'",
+					ValidateFunc: validation.StringInSlice([]string{"all", "any"}, false),
+				},
+				// NerdGraph only. Equivalent to `time_function`,
+				// but with slightly different wording.
+				// i.e. `any` (old) vs `AT_LEAST_ONCE` (new)
+				"threshold_occurrences": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "The criteria for how many data points must be in violation for the specified threshold duration. Valid values are: 'ALL' or 'AT_LEAST_ONCE' (case insensitive).",
+					ValidateFunc: validation.StringInSlice([]string{"ALL", "AT_LEAST_ONCE"}, true),
+					StateFunc: func(v interface{}) string {
+						// Always store lowercase to prevent state drift
+						return strings.ToLower(v.(string))
+					},
+				},
+				// NerdGraph only. Equivalent to `duration`, but in seconds
+				"threshold_duration": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Description: "The duration, in seconds, that the threshold must violate in order to create a violation. Value must be a multiple of the 'aggregation_window' (which has a default of 60 seconds). Value must be within 120-3600 seconds for baseline and outlier conditions, within 120-7200 seconds for static conditions with the sum value function, and within 60-7200 seconds for static conditions with the single_value value function.",
+					// This validation is a top-level validation check. The ranges for each
+					// condition type, and the multiple of the aggregation window, are
+					// checked by validateNrqlConditionTerms.
+					ValidateFunc: validation.IntBetween(60, 7200),
+				},
+			},
+		}
+
+		if priority {
+			term.Schema["priority"] = &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "critical",
+				Description:  "One of (critical, warning). Defaults to 'critical'. At least one condition term must have priority set to 'critical'.",
+				ValidateFunc: validation.StringInSlice([]string{"critical", "warning"}, false),
+			}
+		}
+
+		return term
+	}
+
+	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"policy_id": {
 				Type:        schema.TypeInt,
@@ -205,7 +288,7 @@ func resourceNewRelicNrqlAlertCondition() *schema.Resource {
 				MaxItems:      2,
 				Optional:      true,
 				Description:   "A set of terms for this condition. Max 2 terms allowed - at least one 1 critical term and 1 optional warning term.",
-				Elem:          termSchemaDeprecated(),
+				Elem:          termV0(true),
 				ConflictsWith: []string{"critical", "warning"},
 				Deprecated:    "use `critical` and `warning` attributes instead",
 			},
@@ -214,7 +297,7 @@ func resourceNewRelicNrqlAlertCondition() *schema.Resource {
 				MinItems:      1,
 				MaxItems:      1,
 				Optional:      true,
-				Elem:          termSchema(),
+				Elem:          termV0(false),
 				Description:   "A condition term with priority set to critical.",
 				ConflictsWith: []string{"term"},
 			},
@@ -223,7 +306,7 @@ func resourceNewRelicNrqlAlertCondition() *schema.Resource {
 				MinItems:      1,
 				MaxItems:      1,
 				Optional:      true,
-				Elem:          termSchema(),
+				Elem:          termV0(false),
 				Description:   "A condition term with priority set to warning.",
 				ConflictsWith: []string{"term"},
 			},
@@ -320,6 +403,45 @@ func resourceNewRelicNrqlAlertCondition() *schema.Resource {
 					return (old == "" || old == "none") && (new == "" || new == "none")
 				},
 			},
+			"aggregation_method": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				Description:   "The method that determines when the data in an aggregation window is evaluated. Valid values are: 'EVENT_FLOW', 'EVENT_TIMER', or 'CADENCE' (case insensitive).",
+				ConflictsWith: []string{"nrql.0.evaluation_offset", "nrql.0.since_value"},
+				ValidateFunc:  validation.StringInSlice([]string{"EVENT_FLOW", "EVENT_TIMER", "CADENCE"}, true),
+				StateFunc: func(v interface{}) string {
+					return strings.ToLower(v.(string))
+				},
+			},
+			"aggregation_delay": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "How long, in seconds, to wait for late data before evaluating an aggregation window. Only used with the 'EVENT_FLOW' and 'CADENCE' aggregation methods.",
+				ConflictsWith: []string{"aggregation_timer", "nrql.0.evaluation_offset", "nrql.0.since_value"},
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v, err := strconv.Atoi(val.(string))
+					if err != nil {
+						errs = append(errs, fmt.Errorf("error converting string to int: %#v", err))
+					} else if v < 0 || v > 3600 {
+						errs = append(errs, fmt.Errorf("%q must be between 0 and 3600 inclusive, got: %d", key, v))
+					}
+					return
+				},
+			},
+			"aggregation_timer": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Description:   "How long, in seconds, to wait after the last data point of an aggregation window arrives before evaluating it. Only used with the 'EVENT_TIMER' aggregation method.",
+				ConflictsWith: []string{"aggregation_delay", "nrql.0.evaluation_offset", "nrql.0.since_value"},
+				ValidateFunc:  validation.IntBetween(5, 1200),
+			},
+			"slide_by": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The duration, in seconds, that overlapping aggregation windows slide by. Must be smaller than and a factor of 'aggregation_window'.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
 			"fill_value": {
 				Type:         schema.TypeFloat,
 				Optional:     true,
@@ -348,6 +470,423 @@ func resourceNewRelicNrqlAlertCondition() *schema.Resource {
 	}
 }
 
+func nrqlAlertConditionSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"policy_id": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			ForceNew:    true,
+			Description: "The ID of the policy where this condition should be used.",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The title of the condition.",
+		},
+		"runbook_url": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Runbook URL to display in notifications.",
+		},
+		"enabled": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Whether or not to enable the alert condition.",
+		},
+		// Note: The "outlier" type does NOT exist in NerdGraph yet
+		"type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "static",
+			Description:  "The type of NRQL alert condition to create. Valid values are: 'static', 'outlier', 'baseline'.",
+			ValidateFunc: validation.StringInSlice([]string{"static", "outlier", "baseline"}, false),
+		},
+		"nrql": {
+			Type:        schema.TypeList,
+			Required:    true,
+			MinItems:    1,
+			MaxItems:    1,
+			Description: "A NRQL query.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"query": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"since_value": {
+						Deprecated:    "use `evaluation_offset` attribute instead",
+						Type:          schema.TypeString,
+						Optional:      true,
+						Description:   "NRQL queries are evaluated in one-minute time windows. The start time depends on the value you provide in the NRQL condition's `since_value`.",
+						ConflictsWith: []string{"nrql.0.evaluation_offset"},
+						ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+							valueString := val.(string)
+							v, err := strconv.Atoi(valueString)
+							if err != nil {
+								errs = append(errs, fmt.Errorf("error converting string to int: %#v", err))
+							}
+							if v < 1 || v > 20 {
+								errs = append(errs, fmt.Errorf("%q must be between 0 and 20 inclusive, got: %d", key, v))
+							}
+							return
+						},
+					},
+					// New attribute in NerdGraph. Equivalent to `since_value`.
+					"evaluation_offset": {
+						Type:          schema.TypeInt,
+						Optional:      true,
+						Description:   "NRQL queries are evaluated in one-minute time windows. The start time depends on the value you provide in the NRQL condition's `evaluation_offset`.",
+						ConflictsWith: []string{"nrql.0.since_value"},
+						ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+							v := val.(int)
+							if v < 1 || v > 20 {
+								errs = append(errs, fmt.Errorf("%q must be between 0 and 20 inclusive, got: %d", key, v))
+							}
+							return
+						},
+					},
+				},
+			},
+		},
+		"term": {
+			Type:          schema.TypeSet,
+			MinItems:      1,
+			MaxItems:      2,
+			Optional:      true,
+			Description:   "A set of terms for this condition. Max 2 terms allowed - at least one 1 critical term and 1 optional warning term.",
+			Elem:          termSchemaDeprecated(),
+			ConflictsWith: []string{"critical", "warning"},
+			Deprecated:    "use `critical` and `warning` attributes instead",
+		},
+		"critical": {
+			Type:          schema.TypeList,
+			MinItems:      1,
+			MaxItems:      1,
+			Optional:      true,
+			Elem:          termSchema(),
+			Description:   "A condition term with priority set to critical.",
+			ConflictsWith: []string{"term"},
+		},
+		"warning": {
+			Type:          schema.TypeList,
+			MinItems:      1,
+			MaxItems:      1,
+			Optional:      true,
+			Elem:          termSchema(),
+			Description:   "A condition term with priority set to warning.",
+			ConflictsWith: []string{"term"},
+		},
+		// Outlier ONLY
+		"expected_groups": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Number of expected groups when using outlier detection.",
+		},
+		// Outlier ONLY
+		"ignore_overlap": {
+			Deprecated:    "use `open_violation_on_group_overlap` attribute instead, but use the inverse of your boolean - e.g. if ignore_overlap = false, use open_violation_on_group_overlap = true",
+			Type:          schema.TypeBool,
+			Optional:      true,
+			Description:   "Whether to look for a convergence of groups when using outlier detection.",
+			ConflictsWith: []string{"open_violation_on_group_overlap"},
+		},
+		"violation_time_limit_seconds": {
+			Type:          schema.TypeInt,
+			Optional:      true,
+			Description:   "Sets a time limit, in seconds, that will automatically force-close a long-lasting violation after the time limit you select.  Must be in the range of 300 to 2592000 (inclusive)",
+			ConflictsWith: []string{"violation_time_limit"},
+			AtLeastOneOf:  []string{"violation_time_limit_seconds", "violation_time_limit"},
+			ValidateFunc:  validation.IntBetween(300, 2592000),
+		},
+		// Exists in NerdGraph, but with different values. Conversion
+		// between new:old and old:new is handled via maps in structures file.
+		// Conflicts with `baseline_direction` when using NerdGraph.
+		"value_function": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "Valid values are: 'single_value' or 'sum'",
+			ValidateFunc: validation.StringInSlice([]string{"single_value", "sum"}, true),
+			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+				return strings.EqualFold(old, new) // Case fold this attribute when diffing
+			},
+		},
+		"account_id": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Computed:    true,
+			Description: "The New Relic account ID for managing your NRQL alert conditions.",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The description of the NRQL alert condition.",
+		},
+		"violation_time_limit": {
+			Type:          schema.TypeString,
+			Deprecated:    "use `violation_time_limit_seconds` attribute instead",
+			Optional:      true,
+			Computed:      true,
+			Description:   "Sets a time limit, in hours, that will automatically force-close a long-lasting violation after the time limit you select. Possible values are 'ONE_HOUR', 'TWO_HOURS', 'FOUR_HOURS', 'EIGHT_HOURS', 'TWELVE_HOURS', 'TWENTY_FOUR_HOURS', 'THIRTY_DAYS' (case insensitive).",
+			ConflictsWith: []string{"violation_time_limit_seconds"},
+			AtLeastOneOf:  []string{"violation_time_limit_seconds", "violation_time_limit"},
+			ValidateFunc:  validation.StringInSlice([]string{"ONE_HOUR", "TWO_HOURS", "FOUR_HOURS", "EIGHT_HOURS", "TWELVE_HOURS", "TWENTY_FOUR_HOURS", "THIRTY_DAYS"}, true),
+			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+				return strings.EqualFold(old, new) // Case fold this attribute when diffing
+			},
+		},
+		"open_violation_on_expiration": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Whether to create a new violation to capture that the signal expired.",
+		},
+		"close_violations_on_expiration": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Whether to close all open violations when the signal expires.",
+		},
+		"aggregation_window": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Computed:    true,
+			Description: "The duration of the time window used to evaluate the NRQL query, in seconds.",
+		},
+		"expiration_duration": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "The amount of time (in seconds) to wait before considering the signal expired.",
+		},
+		"fill_option": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "Which strategy to use when filling gaps in the signal. If static, the 'fill value' will be used for filling gaps in the signal. Valid values are: 'NONE', 'LAST_VALUE', or 'STATIC' (case insensitive).",
+			ValidateFunc: validation.StringInSlice([]string{"NONE", "LAST_VALUE", "STATIC"}, true),
+			StateFunc: func(v interface{}) string {
+				// Always store lowercase to prevent state drift
+				return strings.ToLower(v.(string))
+			},
+			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+				// Assume that empty string and 'none' are the same for diff purposes due to API defaults
+				return (old == "" || old == "none") && (new == "" || new == "none")
+			},
+		},
+		"aggregation_method": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Computed:      true,
+			Description:   "The method that determines when the data in an aggregation window is evaluated. Valid values are: 'EVENT_FLOW', 'EVENT_TIMER', or 'CADENCE' (case insensitive).",
+			ConflictsWith: []string{"nrql.0.evaluation_offset", "nrql.0.since_value"},
+			ValidateFunc:  validation.StringInSlice([]string{"EVENT_FLOW", "EVENT_TIMER", "CADENCE"}, true),
+			StateFunc: func(v interface{}) string {
+				return strings.ToLower(v.(string))
+			},
+		},
+		"aggregation_delay": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Description:   "How long, in seconds, to wait for late data before evaluating an aggregation window. Only used with the 'EVENT_FLOW' and 'CADENCE' aggregation methods.",
+			ConflictsWith: []string{"aggregation_timer", "nrql.0.evaluation_offset", "nrql.0.since_value"},
+			ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+				v, err := strconv.Atoi(val.(string))
+				if err != nil {
+					errs = append(errs, fmt.Errorf("error converting string to int: %#v", err))
+				} else if v < 0 || v > 3600 {
+					errs = append(errs, fmt.Errorf("%q must be between 0 and 3600 inclusive, got: %d", key, v))
+				}
+				return
+			},
+		},
+		"aggregation_timer": {
+			Type:          schema.TypeInt,
+			Optional:      true,
+			Description:   "How long, in seconds, to wait after the last data point of an aggregation window arrives before evaluating it. Only used with the 'EVENT_TIMER' aggregation method.",
+			ConflictsWith: []string{"aggregation_delay", "nrql.0.evaluation_offset", "nrql.0.since_value"},
+			ValidateFunc:  validation.IntBetween(5, 1200),
+		},
+		"slide_by": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "The duration, in seconds, that overlapping aggregation windows slide by. Must be smaller than and a factor of 'aggregation_window'.",
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"fill_value": {
+			Type:         schema.TypeFloat,
+			Optional:     true,
+			Description:  "If using the 'static' fill option, this value will be used for filling gaps in the signal.",
+			RequiredWith: []string{"fill_option"},
+		},
+		// Baseline ONLY
+		"baseline_direction": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Description:   "The baseline direction of a baseline NRQL alert condition. Valid values are: 'LOWER_ONLY', 'UPPER_AND_LOWER', 'UPPER_ONLY' (case insensitive).",
+			ConflictsWith: []string{"value_function"},
+			ValidateFunc:  validation.StringInSlice([]string{"LOWER_ONLY", "UPPER_AND_LOWER", "UPPER_ONLY"}, true),
+			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+				return strings.EqualFold(old, new) // Case fold this attribute when diffing
+			},
+		},
+		// Outlier ONLY
+		"open_violation_on_group_overlap": {
+			Type:          schema.TypeBool,
+			Optional:      true,
+			Description:   "Whether overlapping groups should produce a violation.",
+			ConflictsWith: []string{"ignore_overlap"},
+		},
+	}
+}
+
+// nrqlConditionDiff is a planned NRQL condition, as read by its validations.
+// It is implemented by *schema.ResourceDiff, and by the inline conditions of
+// newrelic_alert_policy_bundle.
+type nrqlConditionDiff interface {
+	Get(key string) interface{}
+	GetOk(key string) (interface{}, bool)
+	NewValueKnown(key string) bool
+}
+
+// validateNrqlCondition runs the validations of a NRQL condition that the
+// schema cannot express.
+func validateNrqlCondition(d nrqlConditionDiff) error {
+	validations := []func(nrqlConditionDiff) error{
+		validateNrqlConditionTypeAttributes,
+		validateNrqlConditionTerms,
+		validateNrqlConditionSignal,
+	}
+
+	for _, validate := range validations {
+		if err := validate(d); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateNrqlConditionTypeAttributes checks that attributes specific to one
+// type of condition are only set on conditions of that type.
+func validateNrqlConditionTypeAttributes(d nrqlConditionDiff) error {
+	conditionType := d.Get("type").(string)
+
+	if _, ok := d.GetOk("baseline_direction"); ok && conditionType != "baseline" {
+		return fmt.Errorf("`baseline_direction` can only be used with baseline conditions")
+	}
+
+	if _, ok := d.GetOk("expected_groups"); ok && conditionType != "outlier" {
+		return fmt.Errorf("`expected_groups` can only be used with outlier conditions")
+	}
+
+	if _, ok := d.GetOk("fill_value"); ok && !strings.EqualFold(d.Get("fill_option").(string), "static") {
+		return fmt.Errorf("`fill_value` can only be used when `fill_option` is static")
+	}
+
+	return nil
+}
+
+// validateNrqlConditionTerms checks the terms of a condition against the
+// limits NerdGraph sets for its type, value function and aggregation window.
+func validateNrqlConditionTerms(d nrqlConditionDiff) error {
+	conditionType := d.Get("type").(string)
+
+	// Static conditions with a single_value value function must be within range [60, 7200].
+	// Static conditions with a sum value function must be within range [120, 7200].
+	// Baseline conditions must be within range [120, 3600].
+	// Outlier conditions must be within range [120, 3600].
+	minDuration, maxDuration := 120, 3600
+	conditions := fmt.Sprintf("%s conditions", conditionType)
+
+	if conditionType == "static" {
+		maxDuration = 7200
+
+		if strings.EqualFold(d.Get("value_function").(string), "sum") {
+			conditions = "static conditions with the sum value function"
+		} else {
+			minDuration = 60
+		}
+	}
+
+	aggregationWindow := 0
+	if d.NewValueKnown("aggregation_window") {
+		if aggregationWindow = d.Get("aggregation_window").(int); aggregationWindow == 0 {
+			aggregationWindow = 60
+		}
+	}
+
+	var terms []map[string]interface{}
+
+	for _, t := range d.Get("term").(*schema.Set).List() {
+		terms = append(terms, t.(map[string]interface{}))
+	}
+
+	for _, priority := range []string{"critical", "warning"} {
+		for _, t := range d.Get(priority).([]interface{}) {
+			if t != nil {
+				term := t.(map[string]interface{})
+				term["priority"] = priority
+				terms = append(terms, term)
+			}
+		}
+	}
+
+	for _, term := range terms {
+		name := fmt.Sprintf("the %s term", term["priority"])
+
+		duration := term["threshold_duration"].(int)
+		if duration == 0 {
+			duration = term["duration"].(int) * 60
+		}
+
+		if duration != 0 {
+			if duration < minDuration || duration > maxDuration {
+				return fmt.Errorf("`threshold_duration` of %s must be between %d and %d seconds for %s, got: %d", name, minDuration, maxDuration, conditions, duration)
+			}
+
+			if aggregationWindow != 0 && duration%aggregationWindow != 0 {
+				return fmt.Errorf("`threshold_duration` of %s must be a multiple of `aggregation_window` (%d), got: %d", name, aggregationWindow, duration)
+			}
+		}
+
+		if threshold := term["threshold"].(float64); conditionType == "baseline" && (threshold < 1 || threshold > 1000) {
+			return fmt.Errorf("`threshold` of %s must be between 1 and 1000 for baseline conditions, got: %g", name, threshold)
+		}
+	}
+
+	return nil
+}
+
+// validateNrqlConditionSignal checks which of the signal's aggregation options
+// can be combined.
+func validateNrqlConditionSignal(d nrqlConditionDiff) error {
+	aggregationMethod := strings.ToUpper(d.Get("aggregation_method").(string))
+
+	if _, ok := d.GetOk("aggregation_timer"); ok && aggregationMethod != "EVENT_TIMER" {
+		return fmt.Errorf("`aggregation_timer` can only be used with the EVENT_TIMER `aggregation_method`")
+	}
+
+	if attr, ok := d.GetOk("aggregation_delay"); ok {
+		if aggregationMethod == "EVENT_TIMER" {
+			return fmt.Errorf("`aggregation_delay` cannot be used with the EVENT_TIMER `aggregation_method`, use `aggregation_timer` instead")
+		}
+
+		if delay, err := strconv.Atoi(attr.(string)); err == nil && aggregationMethod == "EVENT_FLOW" && delay > 1200 {
+			return fmt.Errorf("`aggregation_delay` must be at most 1200 seconds with the EVENT_FLOW `aggregation_method`, got: %d", delay)
+		}
+	}
+
+	if slideBy, ok := d.GetOk("slide_by"); ok && d.NewValueKnown("aggregation_window") {
+		aggregationWindow := d.Get("aggregation_window").(int)
+		if aggregationWindow == 0 {
+			aggregationWindow = 60
+		}
+
+		if slideBy.(int) >= aggregationWindow || aggregationWindow%slideBy.(int) != 0 {
+			return fmt.Errorf("`slide_by` must be smaller than and a factor of `aggregation_window` (%d), got: %d", aggregationWindow, slideBy.(int))
+		}
+	}
+
+	return nil
+}
+
 func resourceNewRelicNrqlAlertConditionCreate(d *schema.ResourceData, meta interface{}) error {
 	providerConfig := meta.(*ProviderConfig)
 	client := providerConfig.NewClient
@@ -362,22 +901,12 @@ func resourceNewRelicNrqlAlertConditionCreate(d *schema.ResourceData, meta inter
 
 	log.Printf("[INFO] Creating New Relic NRQL alert condition %s via NerdGraph API", conditionInput.Name)
 
-	var condition *alerts.NrqlAlertCondition
-
-	switch d.Get("type").(string) {
-	case "baseline":
-		condition, err = client.Alerts.CreateNrqlConditionBaselineMutation(accountID, policyID, *conditionInput)
-	case "static":
-		condition, err = client.Alerts.CreateNrqlConditionStaticMutation(accountID, policyID, *conditionInput)
-	case "outlier":
-		condition, err = client.Alerts.CreateNrqlConditionOutlierMutation(accountID, policyID, *conditionInput)
-	}
-
+	id, err := createNrqlCondition(client, accountID, policyID, d.Get("type").(string), conditionInput)
 	if err != nil {
 		return err
 	}
 
-	conditionID, err := strconv.Atoi(condition.ID)
+	conditionID, err := strconv.Atoi(id)
 	if err != nil {
 		return err
 	}
@@ -411,7 +940,7 @@ func resourceNewRelicNrqlAlertConditionRead(d *schema.ResourceData, meta interfa
 		return err
 	}
 
-	nrqlCondition, err := client.Alerts.GetNrqlConditionQuery(accountID, strconv.Itoa(conditionID))
+	nrqlCondition, err := getNrqlCondition(client, accountID, strconv.Itoa(conditionID))
 	if err != nil {
 		if _, ok := err.(*errors.NotFound); ok {
 			d.SetId("")
@@ -440,16 +969,7 @@ func resourceNewRelicNrqlAlertConditionUpdate(d *schema.ResourceData, meta inter
 		return err
 	}
 
-	switch d.Get("type").(string) {
-	case "baseline":
-		_, err = client.Alerts.UpdateNrqlConditionBaselineMutation(accountID, conditionID, *conditionInput)
-	case "static":
-		_, err = client.Alerts.UpdateNrqlConditionStaticMutation(accountID, conditionID, *conditionInput)
-	case "outlier":
-		_, err = client.Alerts.UpdateNrqlConditionOutlierMutation(accountID, conditionID, *conditionInput)
-	}
-
-	if err != nil {
+	if err := updateNrqlCondition(client, accountID, conditionID, d.Get("type").(string), conditionInput); err != nil {
 		return err
 	}
 
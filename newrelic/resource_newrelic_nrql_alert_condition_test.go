@@ -116,7 +116,7 @@ func TestAccNewRelicNrqlAlertCondition_NerdGraphThresholdDurationValidationError
 					"0",
 					conditionalAttrBaseline,
 				),
-				ExpectError: regexp.MustCompile("Validation Error"),
+				ExpectError: regexp.MustCompile("must be between 120 and 3600 seconds"),
 			},
 			// Test: Baseline condition invalid `threshold_duration`
 			{
@@ -129,7 +129,7 @@ func TestAccNewRelicNrqlAlertCondition_NerdGraphThresholdDurationValidationError
 					"0",
 					conditionalAttrBaseline,
 				),
-				ExpectError: regexp.MustCompile("Validation Error"),
+				ExpectError: regexp.MustCompile("must be between 120 and 3600 seconds"),
 			},
 			// Test: Outlier condition invalid `threshold_duration`
 			{
@@ -141,7 +141,7 @@ func TestAccNewRelicNrqlAlertCondition_NerdGraphThresholdDurationValidationError
 					conditionalAttrOutlier,
 					facetClause,
 				),
-				ExpectError: regexp.MustCompile("Validation Error"),
+				ExpectError: regexp.MustCompile("must be between 120 and 3600 seconds"),
 			},
 			// Test: Outlier condition invalid `threshold_duration`
 			{
@@ -153,7 +153,7 @@ func TestAccNewRelicNrqlAlertCondition_NerdGraphThresholdDurationValidationError
 					conditionalAttrOutlier,
 					facetClause,
 				),
-				ExpectError: regexp.MustCompile("Validation Error"),
+				ExpectError: regexp.MustCompile("must be between 120 and 3600 seconds"),
 			},
 		},
 	})
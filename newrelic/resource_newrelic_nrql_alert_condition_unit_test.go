@@ -0,0 +1,401 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccNewRelicNrqlAlertCondition_Offline(t *testing.T) {
+	resourceName := "newrelic_nrql_alert_condition.foo"
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicNrqlAlertConditionConfigOffline(server, "tf-test-condition", 1.5),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test-condition"),
+					resource.TestCheckResourceAttr(resourceName, "type", "static"),
+					resource.TestCheckResourceAttr(resourceName, "value_function", "SINGLE_VALUE"),
+					resource.TestCheckResourceAttr(resourceName, "critical.0.threshold", "1.5"),
+				),
+			},
+			// Test: Update
+			{
+				Config: testAccNewRelicNrqlAlertConditionConfigOffline(server, "tf-test-condition-updated", 2.5),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test-condition-updated"),
+					resource.TestCheckResourceAttr(resourceName, "critical.0.threshold", "2.5"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            testAccNewRelicNrqlAlertConditionConfigOffline(server, "tf-test-condition-updated", 2.5),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"violation_time_limit",         // deprecated in favor of violation_time_limit_seconds
+					"violation_time_limit_seconds", // only read back when configured
+				},
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources[resourceName].Primary.ID + ":static", nil
+				},
+			},
+		},
+	})
+}
+
+func testAccNewRelicNrqlAlertConditionConfigOffline(server *testFakeServer, name string, threshold float64) string {
+	return server.providerConfig() + fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-policy"
+}
+
+resource "newrelic_nrql_alert_condition" "foo" {
+  policy_id = newrelic_alert_policy.foo.id
+
+  name                         = "%s"
+  type                         = "static"
+  runbook_url                  = "https://foo.example.com"
+  description                  = "test description"
+  value_function               = "single_value"
+  violation_time_limit_seconds = 3600
+  aggregation_window           = 60
+  fill_option                  = "static"
+  fill_value                   = 1
+
+  nrql {
+    query             = "SELECT uniqueCount(hostname) FROM ComputeSample"
+    evaluation_offset = 3
+  }
+
+  critical {
+    operator              = "above"
+    threshold             = %g
+    threshold_duration    = 300
+    threshold_occurrences = "ALL"
+  }
+}
+`, name, threshold)
+}
+
+func TestAccNewRelicNrqlAlertCondition_AggregationOffline(t *testing.T) {
+	resourceName := "newrelic_nrql_alert_condition.foo"
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicNrqlAlertConditionAggregationConfigOffline(server, `
+  aggregation_method = "EVENT_TIMER"
+  aggregation_timer  = 30
+  slide_by           = 30
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "aggregation_method", "event_timer"),
+					resource.TestCheckResourceAttr(resourceName, "aggregation_timer", "30"),
+					resource.TestCheckNoResourceAttr(resourceName, "aggregation_delay"),
+					resource.TestCheckResourceAttr(resourceName, "slide_by", "30"),
+				),
+			},
+			// Test: Update
+			{
+				Config: testAccNewRelicNrqlAlertConditionAggregationConfigOffline(server, `
+  aggregation_method = "event_flow"
+  aggregation_delay  = "0"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "aggregation_method", "event_flow"),
+					resource.TestCheckResourceAttr(resourceName, "aggregation_delay", "0"),
+					resource.TestCheckResourceAttr(resourceName, "aggregation_timer", "0"),
+					resource.TestCheckResourceAttr(resourceName, "slide_by", "0"),
+				),
+			},
+			// Test: Import
+			{
+				Config: testAccNewRelicNrqlAlertConditionAggregationConfigOffline(server, `
+  aggregation_method = "event_flow"
+  aggregation_delay  = "0"
+`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"violation_time_limit",         // deprecated in favor of violation_time_limit_seconds
+					"violation_time_limit_seconds", // only read back when configured
+					"aggregation_delay",            // only read back when configured
+					"aggregation_timer",            // only read back when configured
+				},
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources[resourceName].Primary.ID + ":static", nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccNewRelicNrqlAlertCondition_AggregationDefaultsOffline(t *testing.T) {
+	resourceName := "newrelic_nrql_alert_condition.foo"
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: The delay the API defaults to is not read back
+			{
+				Config: testAccNewRelicNrqlAlertConditionAggregationConfigOffline(server, `
+  aggregation_method = "event_flow"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "aggregation_method", "event_flow"),
+					resource.TestCheckNoResourceAttr(resourceName, "aggregation_delay"),
+				),
+			},
+			{
+				Config: testAccNewRelicNrqlAlertConditionAggregationConfigOffline(server, `
+  aggregation_method = "event_flow"
+`),
+				PlanOnly: true,
+			},
+			// Test: Nor is the timer it defaults to
+			{
+				Config: testAccNewRelicNrqlAlertConditionAggregationConfigOffline(server, `
+  aggregation_method = "event_timer"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "aggregation_method", "event_timer"),
+					resource.TestCheckNoResourceAttr(resourceName, "aggregation_timer"),
+				),
+			},
+			{
+				Config: testAccNewRelicNrqlAlertConditionAggregationConfigOffline(server, `
+  aggregation_method = "event_timer"
+`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicNrqlAlertCondition_EvaluationOffsetOffline(t *testing.T) {
+	resourceName := "newrelic_nrql_alert_condition.foo"
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: The aggregation derived from the offset is not read back
+			{
+				Config: testAccNewRelicNrqlAlertConditionOffsetConfigOffline(server, "evaluation_offset = 3"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNrqlAlertConditionAggregationDelayOffline(server, resourceName, 180),
+					resource.TestCheckResourceAttr(resourceName, "nrql.0.evaluation_offset", "3"),
+					resource.TestCheckNoResourceAttr(resourceName, "aggregation_method"),
+					resource.TestCheckNoResourceAttr(resourceName, "aggregation_delay"),
+				),
+			},
+			// Test: Same with the deprecated since_value
+			{
+				Config: testAccNewRelicNrqlAlertConditionOffsetConfigOffline(server, `since_value = "5"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicNrqlAlertConditionAggregationDelayOffline(server, resourceName, 300),
+					resource.TestCheckResourceAttr(resourceName, "nrql.0.since_value", "5"),
+					resource.TestCheckNoResourceAttr(resourceName, "aggregation_method"),
+					resource.TestCheckNoResourceAttr(resourceName, "aggregation_delay"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckNewRelicNrqlAlertConditionAggregationDelayOffline verifies the
+// aggregation delay the fake server returns for the condition.
+func testAccCheckNewRelicNrqlAlertConditionAggregationDelayOffline(server *testFakeServer, n string, delay float64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ids, err := parseIDs(s.RootModule().Resources[n].Primary.ID, 2)
+		if err != nil {
+			return err
+		}
+
+		server.mu.Lock()
+		defer server.mu.Unlock()
+
+		signal, _ := server.nrqlConditions[ids[1]]["signal"].(testFakeObject)
+		if signal["aggregationDelay"] != delay {
+			return fmt.Errorf("expected the fake server to return an aggregation delay of %g, got %v", delay, signal["aggregationDelay"])
+		}
+
+		return nil
+	}
+}
+
+func testAccNewRelicNrqlAlertConditionOffsetConfigOffline(server *testFakeServer, offset string) string {
+	return server.providerConfig() + fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-policy"
+}
+
+resource "newrelic_nrql_alert_condition" "foo" {
+  policy_id = newrelic_alert_policy.foo.id
+
+  name                         = "tf-test-condition"
+  type                         = "static"
+  value_function               = "single_value"
+  violation_time_limit_seconds = 3600
+
+  nrql {
+    query = "SELECT uniqueCount(hostname) FROM ComputeSample"
+    %s
+  }
+
+  critical {
+    operator              = "above"
+    threshold             = 1
+    threshold_duration    = 300
+    threshold_occurrences = "ALL"
+  }
+}
+`, offset)
+}
+
+func TestAccNewRelicNrqlAlertCondition_AggregationValidationOffline(t *testing.T) {
+	server := newTestFakeServer(t)
+
+	cases := map[string]string{
+		"aggregation_timer` can only be used with the EVENT_TIMER": `
+  aggregation_method = "event_flow"
+  aggregation_timer  = 30
+`,
+		"aggregation_delay` cannot be used with the EVENT_TIMER": `
+  aggregation_method = "event_timer"
+  aggregation_delay  = "60"
+`,
+		"aggregation_delay` must be at most 1200 seconds": `
+  aggregation_method = "event_flow"
+  aggregation_delay  = "1800"
+`,
+		"slide_by` must be smaller than and a factor of `aggregation_window` \\(60\\), got: 45": `
+  slide_by = 45
+`,
+	}
+
+	var steps []resource.TestStep
+	for msg, settings := range cases {
+		steps = append(steps, resource.TestStep{
+			Config:      testAccNewRelicNrqlAlertConditionAggregationConfigOffline(server, settings),
+			ExpectError: regexp.MustCompile(msg),
+		})
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps:        steps,
+	})
+}
+
+func TestAccNewRelicNrqlAlertCondition_ValidationOffline(t *testing.T) {
+	server := newTestFakeServer(t)
+
+	staticSum := `
+  type           = "static"
+  value_function = "sum"
+`
+	baseline := `
+  type               = "baseline"
+  baseline_direction = "upper_only"
+`
+
+	cases := []struct {
+		attrs    string
+		critical string
+		err      string
+	}{
+		{staticSum, "threshold = 1\n threshold_duration = 60", "`threshold_duration` of the critical term must be between 120 and 7200 seconds for static conditions with the sum value function, got: 60"},
+		{baseline, "threshold = 1\n threshold_duration = 7200", "`threshold_duration` of the critical term must be between 120 and 3600 seconds for baseline conditions, got: 7200"},
+		{baseline, "threshold = 0.5\n threshold_duration = 300", "`threshold` of the critical term must be between 1 and 1000 for baseline conditions, got: 0.5"},
+		{staticSum + "aggregation_window = 120", "threshold = 1\n threshold_duration = 300", "`threshold_duration` of the critical term must be a multiple of `aggregation_window` \\(120\\), got: 300"},
+		{`type = "outlier"` + "\n" + `baseline_direction = "upper_only"`, "threshold = 1\n threshold_duration = 300", "`baseline_direction` can only be used with baseline conditions"},
+		{baseline + "expected_groups = 2", "threshold = 1\n threshold_duration = 300", "`expected_groups` can only be used with outlier conditions"},
+		{staticSum + "fill_option = \"last_value\"\n fill_value = 1", "threshold = 1\n threshold_duration = 300", "`fill_value` can only be used when `fill_option` is static"},
+	}
+
+	var steps []resource.TestStep
+	for _, tc := range cases {
+		steps = append(steps, resource.TestStep{
+			Config:      testAccNewRelicNrqlAlertConditionCustomConfigOffline(server, tc.attrs, tc.critical),
+			ExpectError: regexp.MustCompile(tc.err),
+		})
+	}
+
+	// A threshold duration that is a multiple of the aggregation window, but
+	// not of 60 seconds.
+	steps = append(steps, resource.TestStep{
+		Config:             testAccNewRelicNrqlAlertConditionCustomConfigOffline(server, staticSum+"aggregation_window = 90", "threshold = 1\n threshold_duration = 270"),
+		PlanOnly:           true,
+		ExpectNonEmptyPlan: true,
+	})
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps:        steps,
+	})
+}
+
+func testAccNewRelicNrqlAlertConditionAggregationConfigOffline(server *testFakeServer, settings string) string {
+	return testAccNewRelicNrqlAlertConditionCustomConfigOffline(server, `
+  type               = "static"
+  value_function     = "single_value"
+  aggregation_window = 60
+`+settings, `
+    threshold          = 1
+    threshold_duration = 300
+`)
+}
+
+func testAccNewRelicNrqlAlertConditionCustomConfigOffline(server *testFakeServer, attrs string, critical string) string {
+	return server.providerConfig() + fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-policy"
+}
+
+resource "newrelic_nrql_alert_condition" "foo" {
+  policy_id = newrelic_alert_policy.foo.id
+
+  name                         = "tf-test-condition"
+  violation_time_limit_seconds = 3600
+%s
+  nrql {
+    query = "SELECT uniqueCount(hostname) FROM ComputeSample"
+  }
+
+  critical {
+    operator              = "above"
+    threshold_occurrences = "ALL"
+%s
+  }
+}
+`, attrs, critical)
+}
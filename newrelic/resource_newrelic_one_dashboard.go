@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/newrelic/newrelic-client-go/pkg/entities"
 	"github.com/newrelic/newrelic-client-go/pkg/errors"
@@ -19,6 +21,7 @@ func resourceNewRelicOneDashboard() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: customdiff.All(customizeDiffDefaultTags, validateDashboardVariables),
 		Schema: map[string]*schema.Schema{
 			// Required
 			"name": {
@@ -51,7 +54,14 @@ func resourceNewRelicOneDashboard() *schema.Resource {
 				ValidateFunc: validation.StringInSlice([]string{"private", "public_read_only", "public_read_write"}, false),
 				Description:  "Determines who can see or edit the dashboard. Valid values are private, public_read_only, public_read_write. Defaults to public_read_only.",
 			},
+			"variable": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Dashboard-local variable definitions, referenced in the NRQL queries of widgets as {{name}}.",
+				Elem:        dashboardVariableSchemaElem(),
+			},
 			// Computed
+			"default_tags": defaultTagsSchema(),
 			"guid": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -97,7 +107,7 @@ func dashboardPageSchemaElem() *schema.Resource {
 				Type:        schema.TypeList,
 				Optional:    true,
 				Description: "A bar widget.",
-				Elem:        dashboardWidgetGraphSchemaElem(),
+				Elem:        dashboardWidgetFacetSchemaElem(),
 			},
 			"widget_billboard": {
 				Type:        schema.TypeList,
@@ -105,10 +115,52 @@ func dashboardPageSchemaElem() *schema.Resource {
 				Description: "A billboard widget.",
 				Elem:        dashboardWidgetBillboardSchemaElem(),
 			},
+			"widget_bullet": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A bullet widget.",
+				Elem:        dashboardWidgetBulletSchemaElem(),
+			},
+			"widget_event_feed": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "An event feed widget.",
+				Elem:        dashboardWidgetGraphSchemaElem(),
+			},
+			"widget_funnel": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A funnel widget.",
+				Elem:        dashboardWidgetGraphSchemaElem(),
+			},
+			"widget_heatmap": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A heatmap widget.",
+				Elem:        dashboardWidgetGraphSchemaElem(),
+			},
+			"widget_histogram": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A histogram widget.",
+				Elem:        dashboardWidgetGraphSchemaElem(),
+			},
+			"widget_json": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A JSON widget.",
+				Elem:        dashboardWidgetGraphSchemaElem(),
+			},
 			"widget_line": {
 				Type:        schema.TypeList,
 				Optional:    true,
 				Description: "A line widget.",
+				Elem:        dashboardWidgetFacetSchemaElem(),
+			},
+			"widget_log_table": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A log table widget.",
 				Elem:        dashboardWidgetGraphSchemaElem(),
 			},
 			"widget_markdown": {
@@ -121,18 +173,158 @@ func dashboardPageSchemaElem() *schema.Resource {
 				Type:        schema.TypeList,
 				Optional:    true,
 				Description: "A pie widget.",
+				Elem:        dashboardWidgetFacetSchemaElem(),
+			},
+			"widget_raw": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A widget of any visualization, configured with its raw JSON configuration.",
+				Elem:        dashboardWidgetRawSchemaElem(),
+			},
+			"widget_stacked_bar": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A stacked bar widget.",
 				Elem:        dashboardWidgetGraphSchemaElem(),
 			},
 			"widget_table": {
 				Type:        schema.TypeList,
 				Optional:    true,
 				Description: "A table widget.",
-				Elem:        dashboardWidgetGraphSchemaElem(),
+				Elem:        dashboardWidgetFacetSchemaElem(),
 			},
 		},
 	}
 }
 
+// dashboardVariableSchemaElem returns the schema for a dashboard variable
+func dashboardVariableSchemaElem() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The variable identifier, referenced in NRQL queries as {{name}}.",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"enum", "nrql", "string"}, false),
+				Description:  "Specifies the data type of the variable and where its possible values may come from. Valid values are enum, nrql and string.",
+			},
+			"title": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Human-friendly display string for this variable.",
+			},
+			"default_values": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The default values of the variable.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"is_multi_selection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether several values of the variable can be selected at once.",
+			},
+			"item": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The possible values of an enum variable.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"title": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "A human-friendly display string for this value.",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "A possible value of the variable.",
+						},
+					},
+				},
+			},
+			"nrql_query": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "The query whose results are the possible values of a nrql variable.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"account_ids": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Description: "The accounts the query runs against.",
+							Elem: &schema.Schema{
+								Type: schema.TypeInt,
+							},
+						},
+						"query": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The NRQL query, whose first attribute selected provides the possible values.",
+						},
+					},
+				},
+			},
+			"replacement_strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "default",
+				ValidateFunc: validation.StringInSlice([]string{"default", "identifier", "number", "string"}, false),
+				Description:  "Indicates the strategy to apply when replacing a variable in a NRQL query. Valid values are default, identifier, number and string. Defaults to default.",
+			},
+		},
+	}
+}
+
+// validateDashboardVariables checks that each variable has the source of values
+// of its type, and only that one.
+func validateDashboardVariables(d *schema.ResourceDiff, meta interface{}) error {
+	names := map[string]bool{}
+
+	for _, v := range d.Get("variable").([]interface{}) {
+		variable := v.(map[string]interface{})
+		name := variable["name"].(string)
+		variableType := variable["type"].(string)
+
+		if name != "" && names[name] {
+			return fmt.Errorf("the names of `variable` blocks must be unique, got %s more than once", name)
+		}
+		names[name] = true
+
+		hasItems := len(variable["item"].([]interface{})) > 0
+		hasQuery := len(variable["nrql_query"].([]interface{})) > 0
+
+		switch variableType {
+		case "enum":
+			if !hasItems {
+				return fmt.Errorf("variable %s of type enum requires at least one `item` block", name)
+			}
+		case "nrql":
+			if !hasQuery {
+				return fmt.Errorf("variable %s of type nrql requires a `nrql_query` block", name)
+			}
+		}
+
+		if hasItems && variableType != "enum" {
+			return fmt.Errorf("`item` blocks are only supported by variables of type enum, variable %s is of type %s", name, variableType)
+		}
+
+		if hasQuery && variableType != "nrql" {
+			return fmt.Errorf("`nrql_query` blocks are only supported by variables of type nrql, variable %s is of type %s", name, variableType)
+		}
+	}
+
+	return nil
+}
+
 // dashboardWidgetNRQLQuerySchemaElem defines a NRQL query for use on a dashboard
 //
 // see: newrelic/newrelic-client-go/pkg/entities/DashboardWidgetQuery
@@ -247,6 +439,84 @@ func dashboardWidgetGraphSchemaElem() *schema.Resource {
 		},
 	}
 }
+
+// dashboardWidgetFacetSchemaElem is a graph whose facets can filter the
+// current dashboard or open linked dashboards
+func dashboardWidgetFacetSchemaElem() *schema.Resource {
+	elem := dashboardWidgetGraphSchemaElem()
+
+	elem.Schema["filter_current_dashboard"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether a click on a facet of the widget filters the current dashboard.",
+	}
+	elem.Schema["linked_entity_guids"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "The GUIDs of the dashboards the facets of the widget link to.",
+	}
+
+	return elem
+}
+
+// dashboardWidgetBulletSchemaElem is a graph with a limit
+func dashboardWidgetBulletSchemaElem() *schema.Resource {
+	elem := dashboardWidgetGraphSchemaElem()
+
+	elem.Schema["limit"] = &schema.Schema{
+		Type:        schema.TypeFloat,
+		Optional:    true,
+		Description: "The maximum value of the bullet chart.",
+	}
+
+	return elem
+}
+
+// dashboardWidgetRawSchemaElem is a widget of any visualization, configured
+// with the JSON configuration of the visualization
+func dashboardWidgetRawSchemaElem() *schema.Resource {
+	elem := dashboardWidgetGraphSchemaElem()
+
+	delete(elem.Schema, "nrql_query")
+
+	elem.Schema["visualization_id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validateDashboardRawWidgetVisualization,
+		Description:  "The ID of the visualization, e.g. viz.gauge or the ID of a custom visualization. Visualizations with their own widget block, such as viz.area, must use that block instead.",
+	}
+	elem.Schema["configuration"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validateJSONObject,
+		StateFunc: func(v interface{}) string {
+			json, _ := structure.NormalizeJsonString(v)
+			return json
+		},
+		Description: "The configuration of the visualization, as a JSON object.",
+	}
+
+	return elem
+}
+
+// validateDashboardRawWidgetVisualization rejects the visualizations with a
+// dedicated widget block, which would be read back as such.
+func validateDashboardRawWidgetVisualization(i interface{}, k string) (s []string, es []error) {
+	v, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if widgetType := dashboardWidgetType(v); widgetType != "widget_raw" {
+		es = append(es, fmt.Errorf("expected %s to be a visualization without a widget block, use %s for %s", k, widgetType, v))
+	}
+
+	return
+}
+
 func dashboardWidgetMarkdownSchemaElem() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
@@ -306,13 +576,34 @@ func resourceNewRelicOneDashboardCreate(d *schema.ResourceData, meta interface{}
 
 	log.Printf("[INFO] Creating New Relic One dashboard: %s", dashboard.Name)
 
-	created, err := client.Dashboards.DashboardCreate(accountID, *dashboard)
+	// The pages of a new dashboard have no GUID yet, so the widgets filtering
+	// the current dashboard are linked to their page once it is created.
+	pending := dashboard.linkFilterCurrentDashboard()
+
+	guid, err := createDashboard(client, accountID, dashboard)
 	if err != nil {
 		return err
 	}
-	guid := created.EntityResult.GUID
 	d.SetId(string(guid))
 
+	if pending {
+		created, _, err := getDashboard(client, guid)
+		if err != nil {
+			return err
+		}
+
+		dashboard.setPageGUIDs(created.Pages)
+		dashboard.linkFilterCurrentDashboard()
+
+		if _, err := updateDashboard(client, guid, dashboard); err != nil {
+			return err
+		}
+	}
+
+	if err := updateDefaultTags(d, meta, guid); err != nil {
+		return err
+	}
+
 	return resourceNewRelicOneDashboardRead(d, meta)
 }
 
@@ -328,7 +619,7 @@ func resourceNewRelicOneDashboardRead(d *schema.ResourceData, meta interface{})
 
 	log.Printf("[INFO] Reading New Relic One dashboard %s", d.Id())
 
-	dashboard, err := client.Dashboards.GetDashboardEntity(entities.EntityGUID(d.Id()))
+	dashboard, variables, err := getDashboard(client, entities.EntityGUID(d.Id()))
 	if err != nil {
 		if _, ok := err.(*errors.NotFound); ok {
 			d.SetId("")
@@ -338,7 +629,11 @@ func resourceNewRelicOneDashboardRead(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
-	return flattenDashboardEntity(dashboard, d)
+	if err := flattenDashboardEntity(dashboard, variables, d); err != nil {
+		return err
+	}
+
+	return readDefaultTags(d, meta, dashboard.GUID)
 }
 
 func resourceNewRelicOneDashboardUpdate(d *schema.ResourceData, meta interface{}) error {
@@ -357,11 +652,29 @@ func resourceNewRelicOneDashboardUpdate(d *schema.ResourceData, meta interface{}
 
 	log.Printf("[INFO] Updating New Relic One dashboard '%s' (%s)", dashboard.Name, d.Id())
 
-	result, err := client.Dashboards.DashboardUpdate(*dashboard, entities.EntityGUID(d.Id()))
+	pending := dashboard.linkFilterCurrentDashboard()
+
+	result, err := updateDashboard(client, entities.EntityGUID(d.Id()), dashboard)
 	if err != nil {
 		return err
 	}
 
+	// The widgets filtering the current dashboard on new pages are linked once
+	// the pages are created.
+	if pending {
+		dashboard.setPageGUIDs(result.Pages)
+		dashboard.linkFilterCurrentDashboard()
+
+		result, err = updateDashboard(client, entities.EntityGUID(d.Id()), dashboard)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := updateDefaultTags(d, meta, entities.EntityGUID(d.Id())); err != nil {
+		return err
+	}
+
 	// We have to use the Update Result, not a re-read of the entity as the changes take
 	// some amount of time to be re-indexed
 	return flattenDashboardUpdateResult(result, d)
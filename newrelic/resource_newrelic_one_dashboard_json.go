@@ -0,0 +1,209 @@
+package newrelic
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
+	"github.com/newrelic/newrelic-client-go/pkg/entities"
+	"github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+func resourceNewRelicOneDashboardJSON() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicOneDashboardJSONCreate,
+		Read:   resourceNewRelicOneDashboardJSONRead,
+		Update: resourceNewRelicOneDashboardJSONUpdate,
+		Delete: resourceNewRelicOneDashboardDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffDefaultTags,
+			// The JSON planned is not normalized yet, so it is compared with the
+			// state once normalized.
+			customdiff.ComputedIf("name", func(d *schema.ResourceDiff, meta interface{}) bool {
+				o, n := d.GetChange("json")
+				oldJSON, _ := structure.NormalizeJsonString(o)
+				newJSON, _ := structure.NormalizeJsonString(n)
+				return oldJSON != newJSON
+			}),
+		),
+		Schema: map[string]*schema.Schema{
+			// Required
+			"json": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateJSONObject,
+				StateFunc: func(v interface{}) string {
+					json, _ := structure.NormalizeJsonString(v)
+					return json
+				},
+				Description: "The dashboard JSON, as exported from the New Relic One UI.",
+			},
+			// Optional
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The New Relic account ID where you want to create the dashboard.",
+			},
+			"replace_account_ids": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to replace the account IDs of the NRQL queries and variables in the JSON with the account of the dashboard.",
+			},
+			// Computed
+			"default_tags": defaultTagsSchema(),
+			"guid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The unique entity identifier of the dashboard in New Relic.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The dashboard's name.",
+			},
+			"permalink": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the dashboard.",
+			},
+		},
+	}
+}
+
+func resourceNewRelicOneDashboardJSONCreate(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return fmt.Errorf("err: NerdGraph support not present, but required for Create")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	dashboard, err := expandDashboardJSONInput(d, accountID)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Creating New Relic One dashboard from JSON: %s", dashboard.Name)
+
+	guid, err := createDashboard(client, accountID, dashboard)
+	if err != nil {
+		return err
+	}
+	d.SetId(string(guid))
+
+	if err := updateDefaultTags(d, meta, guid); err != nil {
+		return err
+	}
+
+	return resourceNewRelicOneDashboardJSONRead(d, meta)
+}
+
+// resourceNewRelicOneDashboardJSONRead reads the attributes computed from the
+// dashboard. The JSON is kept as configured, as the dashboard read back has
+// the IDs and defaults assigned by New Relic, unless the pages, widgets or
+// variables of the dashboard differ from it. It is then replaced with the
+// dashboard read back, which is also the JSON of an imported dashboard.
+func resourceNewRelicOneDashboardJSONRead(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return fmt.Errorf("err: NerdGraph support not present, but required for Read")
+	}
+
+	client := providerConfig.NewClient
+
+	log.Printf("[INFO] Reading New Relic One dashboard %s", d.Id())
+
+	dashboard, variables, err := getDashboard(client, entities.EntityGUID(d.Id()))
+	if err != nil {
+		if _, ok := err.(*errors.NotFound); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	actual, err := flattenDashboardJSON(dashboard, variables)
+	if err != nil {
+		return err
+	}
+
+	// There is no JSON on import, and a JSON left in the state by a failed
+	// update may not be valid, both differ from the dashboard.
+	expected := ""
+	if input, err := expandDashboardJSONInput(d, dashboard.AccountID); err == nil {
+		if expected, err = flattenDashboardJSONInput(input); err != nil {
+			return err
+		}
+	}
+
+	// The defaults New Relic adds for what the JSON leaves out, such as the
+	// replacementStrategy of a variable, are not changes.
+	if expected != "" {
+		if actual, err = filterDashboardConfiguredJSON(actual, expected); err != nil {
+			return err
+		}
+	}
+
+	if actual != expected {
+		d.Set("json", actual)
+	}
+
+	d.Set("account_id", dashboard.AccountID)
+	d.Set("guid", dashboard.GUID)
+	d.Set("name", dashboard.Name)
+	d.Set("permalink", dashboard.Permalink)
+
+	return readDefaultTags(d, meta, dashboard.GUID)
+}
+
+func resourceNewRelicOneDashboardJSONUpdate(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return fmt.Errorf("err: NerdGraph support not present, but required for Update")
+	}
+
+	client := providerConfig.NewClient
+	guid := entities.EntityGUID(d.Id())
+
+	dashboard, err := expandDashboardJSONInput(d, selectAccountID(providerConfig, d))
+	if err != nil {
+		return err
+	}
+
+	// Exported pages have no GUID, so the current pages are updated in order
+	// rather than replaced, keeping the GUIDs that widgets may link to.
+	current, _, err := getDashboard(client, guid)
+	if err != nil {
+		return err
+	}
+	dashboard.setPageGUIDs(current.Pages)
+
+	log.Printf("[INFO] Updating New Relic One dashboard '%s' (%s) from JSON", dashboard.Name, d.Id())
+
+	result, err := updateDashboard(client, guid, dashboard)
+	if err != nil {
+		return err
+	}
+
+	if err := updateDefaultTags(d, meta, guid); err != nil {
+		return err
+	}
+
+	// The update result is used rather than a re-read of the entity, as the
+	// changes take some time to be re-indexed.
+	d.Set("name", result.Name)
+
+	return nil
+}
@@ -0,0 +1,206 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccNewRelicOneDashboardJSON_Offline(t *testing.T) {
+	resourceName := "newrelic_one_dashboard_json.foo"
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicOneDashboardJSONConfigOffline(server, "tf-test-dashboard", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "guid"),
+					resource.TestCheckResourceAttrSet(resourceName, "permalink"),
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test-dashboard"),
+					resource.TestCheckResourceAttr(resourceName, "account_id", fmt.Sprint(testFakeAccountID)),
+					testAccCheckNewRelicOneDashboardJSONAccountIDsOffline(server, resourceName, 9999999),
+				),
+			},
+			// Test: The defaults New Relic adds are not changes
+			{
+				PreConfig: func() {
+					server.mu.Lock()
+					defer server.mu.Unlock()
+
+					for _, dashboard := range server.dashboards {
+						variable := testFakeList(dashboard["variables"])[0].(testFakeObject)
+						if variable["replacementStrategy"] != "DEFAULT" {
+							t.Errorf("expected the default replacement strategy, got %v", variable["replacementStrategy"])
+						}
+					}
+				},
+				Config:   testAccNewRelicOneDashboardJSONConfigOffline(server, "tf-test-dashboard", false),
+				PlanOnly: true,
+			},
+			// Test: Semantically equal JSON has no diff
+			{
+				Config:   strings.Replace(testAccNewRelicOneDashboardJSONConfigOffline(server, "tf-test-dashboard", false), "  ", "    ", -1),
+				PlanOnly: true,
+			},
+			// Test: Changes made outside of Terraform are detected
+			{
+				PreConfig: func() {
+					server.mu.Lock()
+					defer server.mu.Unlock()
+
+					for _, dashboard := range server.dashboards {
+						dashboard["pages"].([]testFakeObject)[0]["widgets"].([]testFakeObject)[0]["title"] = "Changed"
+					}
+				},
+				Config:             testAccNewRelicOneDashboardJSONConfigOffline(server, "tf-test-dashboard", false),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Test: The changes are reverted
+			{
+				Config: testAccNewRelicOneDashboardJSONConfigOffline(server, "tf-test-dashboard", false),
+				Check:  testAccCheckNewRelicOneDashboardJSONWidgetTitleOffline(server, resourceName, "Throughput"),
+			},
+			// Test: Update, replacing the account IDs
+			{
+				Config: testAccNewRelicOneDashboardJSONConfigOffline(server, "tf-test-dashboard-updated", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test-dashboard-updated"),
+					testAccCheckNewRelicOneDashboardJSONAccountIDsOffline(server, resourceName, testFakeAccountID),
+				),
+			},
+			// Test: Import
+			{
+				Config:                  testAccNewRelicOneDashboardJSONConfigOffline(server, "tf-test-dashboard-updated", true),
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"json", "replace_account_ids"},
+				ImportStateCheck: func(s []*terraform.InstanceState) error {
+					if !strings.Contains(s[0].Attributes["json"], `"title":"Throughput"`) {
+						return fmt.Errorf("expected the JSON of the dashboard, got %s", s[0].Attributes["json"])
+					}
+					return nil
+				},
+			},
+			// Test: The JSON must describe a dashboard
+			{
+				Config: server.providerConfig() + `
+resource "newrelic_one_dashboard_json" "foo" {
+  json = jsonencode({ pages = [] })
+}
+`,
+				ExpectError: regexp.MustCompile("invalid dashboard JSON: name is required"),
+			},
+		},
+	})
+}
+
+// testAccCheckNewRelicOneDashboardJSONAccountIDsOffline verifies the account
+// IDs of the widget queries and variables of a dashboard on the fake server.
+func testAccCheckNewRelicOneDashboardJSONAccountIDsOffline(server *testFakeServer, n string, accountID int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		guid := s.RootModule().Resources[n].Primary.ID
+
+		server.mu.Lock()
+		defer server.mu.Unlock()
+
+		dashboard, ok := server.dashboards[guid]
+		if !ok {
+			return fmt.Errorf("dashboard %s not found", guid)
+		}
+
+		widget := dashboard["pages"].([]testFakeObject)[0]["widgets"].([]testFakeObject)[0]
+		query := testFakeList(widget["rawConfiguration"].(testFakeObject)["nrqlQueries"])[0].(testFakeObject)
+		if query["accountId"] != float64(accountID) {
+			return fmt.Errorf("expected the widget query of account %d, got %v", accountID, query["accountId"])
+		}
+
+		variable := testFakeList(dashboard["variables"])[0].(testFakeObject)
+		accountIDs := testFakeList(variable["nrqlQuery"].(testFakeObject)["accountIds"])
+		if len(accountIDs) != 1 || accountIDs[0] != float64(accountID) {
+			return fmt.Errorf("expected the variable query of account %d, got %v", accountID, accountIDs)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckNewRelicOneDashboardJSONWidgetTitleOffline verifies the title of
+// the widget of a dashboard on the fake server.
+func testAccCheckNewRelicOneDashboardJSONWidgetTitleOffline(server *testFakeServer, n string, title string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		guid := s.RootModule().Resources[n].Primary.ID
+
+		server.mu.Lock()
+		defer server.mu.Unlock()
+
+		dashboard, ok := server.dashboards[guid]
+		if !ok {
+			return fmt.Errorf("dashboard %s not found", guid)
+		}
+
+		widget := dashboard["pages"].([]testFakeObject)[0]["widgets"].([]testFakeObject)[0]
+		if widget["title"] != title {
+			return fmt.Errorf("expected the widget title %q, got %v", title, widget["title"])
+		}
+
+		return nil
+	}
+}
+
+func testAccNewRelicOneDashboardJSONConfigOffline(server *testFakeServer, name string, replaceAccountIDs bool) string {
+	return server.providerConfig() + fmt.Sprintf(`
+resource "newrelic_one_dashboard_json" "foo" {
+  replace_account_ids = %[2]t
+
+  json = <<EOT
+{
+  "name": "%[1]s",
+  "description": null,
+  "permissions": "PUBLIC_READ_WRITE",
+  "pages": [
+    {
+      "name": "%[1]s",
+      "description": null,
+      "widgets": [
+        {
+          "title": "Throughput",
+          "layout": { "column": 1, "row": 1, "width": 4, "height": 3 },
+          "linkedEntityGuids": null,
+          "visualization": { "id": "viz.gauge" },
+          "rawConfiguration": {
+            "limit": 100,
+            "nrqlQueries": [
+              { "accountId": 9999999, "query": "FROM Transaction SELECT rate(count(*), 1 minute) WHERE appName IN ({{appName}})" }
+            ]
+          }
+        }
+      ]
+    }
+  ],
+  "variables": [
+    {
+      "name": "appName",
+      "title": "Application",
+      "type": "NRQL",
+      "isMultiSelection": true,
+      "nrqlQuery": { "accountIds": [9999999], "query": "FROM Transaction SELECT uniques(appName)" }
+    }
+  ]
+}
+EOT
+}
+`, name, replaceAccountIDs)
+}
@@ -159,6 +159,7 @@ func testAccCheckNewRelicOneDashboardConfig_PageFull(pageName string, accountID
       title = "foo"
       row = 4
       column = 1
+      filter_current_dashboard = true
       nrql_query {
         account_id = ` + accountID + `
         query      = "FROM Transaction SELECT count(*) FACET name"
@@ -218,6 +219,88 @@ func testAccCheckNewRelicOneDashboardConfig_PageFull(pageName string, accountID
         query      = "FROM Transaction SELECT *"
       }
     }
+
+    widget_bullet {
+      title = "Bullseye"
+      row = 10
+      column = 1
+      nrql_query {
+        account_id = ` + accountID + `
+        query      = "FROM Transaction SELECT average(duration)"
+      }
+
+      limit = 1.5
+    }
+
+    widget_event_feed {
+      title = "Events"
+      row = 10
+      column = 5
+      nrql_query {
+        account_id = ` + accountID + `
+        query      = "FROM Transaction SELECT *"
+      }
+    }
+
+    widget_funnel {
+      title = "Funnel"
+      row = 10
+      column = 9
+      nrql_query {
+        account_id = ` + accountID + `
+        query      = "FROM PageView SELECT funnel(session, WHERE pageUrl LIKE '%/home', WHERE pageUrl LIKE '%/checkout')"
+      }
+    }
+
+    widget_heatmap {
+      title = "Heat"
+      row = 13
+      column = 1
+      nrql_query {
+        account_id = ` + accountID + `
+        query      = "FROM Transaction SELECT histogram(duration, buckets: 100, width: 0.1) FACET appName"
+      }
+    }
+
+    widget_histogram {
+      title = "Histogram"
+      row = 13
+      column = 5
+      nrql_query {
+        account_id = ` + accountID + `
+        query      = "FROM Transaction SELECT histogram(duration, buckets: 100, width: 0.1)"
+      }
+    }
+
+    widget_json {
+      title = "JSON"
+      row = 13
+      column = 9
+      nrql_query {
+        account_id = ` + accountID + `
+        query      = "FROM Transaction SELECT count(*) FACET name"
+      }
+    }
+
+    widget_log_table {
+      title = "Logs"
+      row = 16
+      column = 1
+      nrql_query {
+        account_id = ` + accountID + `
+        query      = "FROM Log SELECT *"
+      }
+    }
+
+    widget_stacked_bar {
+      title = "Stacked"
+      row = 16
+      column = 5
+      nrql_query {
+        account_id = ` + accountID + `
+        query      = "FROM Transaction SELECT count(*) FACET name TIMESERIES"
+      }
+    }
   }
 `
 }
@@ -0,0 +1,522 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccNewRelicOneDashboard_RawWidgetsOffline(t *testing.T) {
+	resourceName := "newrelic_one_dashboard.foo"
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicOneDashboardRawWidgetsConfigOffline(server, "FROM Transaction SELECT average(duration)", 1.5),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "guid"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_bullet.#", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "page.0.widget_bullet.0.id"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_bullet.0.limit", "1.5"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_bullet.0.nrql_query.0.query", "FROM Transaction SELECT average(duration)"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_event_feed.0.title", "Events"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_funnel.0.nrql_query.0.query", "FROM PageView SELECT funnel(session, WHERE pageUrl LIKE '%/home', WHERE pageUrl LIKE '%/checkout')"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_heatmap.0.nrql_query.0.account_id", fmt.Sprint(testFakeAccountID)),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_histogram.0.row", "4"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_json.0.width", "6"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_log_table.0.nrql_query.0.query", "FROM Log SELECT *"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_stacked_bar.0.nrql_query.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_table.#", "1"),
+				),
+			},
+			// Test: Update
+			{
+				Config: testAccNewRelicOneDashboardRawWidgetsConfigOffline(server, "FROM Transaction SELECT max(duration)", 3),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_bullet.0.limit", "3"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_bullet.0.nrql_query.0.query", "FROM Transaction SELECT max(duration)"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            testAccNewRelicOneDashboardRawWidgetsConfigOffline(server, "FROM Transaction SELECT max(duration)", 3),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNewRelicOneDashboardRawWidgetsConfigOffline(server *testFakeServer, bulletQuery string, limit float64) string {
+	return server.providerConfig() + fmt.Sprintf(`
+resource "newrelic_one_dashboard" "foo" {
+  name = "tf-test-dashboard"
+
+  page {
+    name = "tf-test-page"
+
+    widget_bullet {
+      title  = "Bullseye"
+      row    = 1
+      column = 1
+      limit  = %[2]g
+
+      nrql_query {
+        account_id = %[1]d
+        query      = "%[3]s"
+      }
+    }
+
+    widget_event_feed {
+      title  = "Events"
+      row    = 1
+      column = 5
+
+      nrql_query {
+        account_id = %[1]d
+        query      = "FROM Transaction SELECT *"
+      }
+    }
+
+    widget_funnel {
+      title  = "Funnel"
+      row    = 1
+      column = 9
+
+      nrql_query {
+        account_id = %[1]d
+        query      = "FROM PageView SELECT funnel(session, WHERE pageUrl LIKE '%%/home', WHERE pageUrl LIKE '%%/checkout')"
+      }
+    }
+
+    widget_heatmap {
+      title  = "Heat"
+      row    = 4
+      column = 1
+
+      nrql_query {
+        account_id = %[1]d
+        query      = "FROM Transaction SELECT histogram(duration, buckets: 100, width: 0.1) FACET appName"
+      }
+    }
+
+    widget_histogram {
+      title  = "Histogram"
+      row    = 4
+      column = 5
+
+      nrql_query {
+        account_id = %[1]d
+        query      = "FROM Transaction SELECT histogram(duration, buckets: 100, width: 0.1)"
+      }
+    }
+
+    widget_json {
+      title  = "JSON"
+      row    = 4
+      column = 7
+      width  = 6
+
+      nrql_query {
+        account_id = %[1]d
+        query      = "FROM Transaction SELECT count(*) FACET name"
+      }
+    }
+
+    widget_log_table {
+      title  = "Logs"
+      row    = 7
+      column = 1
+
+      nrql_query {
+        account_id = %[1]d
+        query      = "FROM Log SELECT *"
+      }
+    }
+
+    widget_stacked_bar {
+      title  = "Stacked"
+      row    = 7
+      column = 5
+
+      nrql_query {
+        account_id = %[1]d
+        query      = "FROM Transaction SELECT count(*) FACET name TIMESERIES"
+      }
+
+      nrql_query {
+        account_id = %[1]d
+        query      = "FROM Transaction SELECT average(duration) FACET name TIMESERIES"
+      }
+    }
+
+    widget_table {
+      title  = "Round"
+      row    = 7
+      column = 9
+
+      nrql_query {
+        account_id = %[1]d
+        query      = "FROM Transaction SELECT *"
+      }
+    }
+  }
+}
+`, testFakeAccountID, limit, bulletQuery)
+}
+
+func TestAccNewRelicOneDashboard_RawWidgetOffline(t *testing.T) {
+	resourceName := "newrelic_one_dashboard.foo"
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicOneDashboardRawWidgetConfigOffline(server, "viz.gauge", `{
+        "nrqlQueries": [{ "accountId": 1, "query": "FROM Transaction SELECT average(duration)" }],
+        "limit": 10
+      }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_raw.#", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "page.0.widget_raw.0.id"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_raw.0.visualization_id", "viz.gauge"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_raw.0.configuration", `{"limit":10,"nrqlQueries":[{"accountId":1,"query":"FROM Transaction SELECT average(duration)"}]}`),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_line.#", "1"),
+				),
+			},
+			// Test: Semantically equal configurations have no diff
+			{
+				Config: testAccNewRelicOneDashboardRawWidgetConfigOffline(server, "viz.gauge", `{
+        "limit": 10,
+        "nrqlQueries": [{ "query": "FROM Transaction SELECT average(duration)", "accountId": 1 }]
+      }`),
+				PlanOnly: true,
+			},
+			// Test: Visualizations with a widget block are rejected
+			{
+				Config:      testAccNewRelicOneDashboardRawWidgetConfigOffline(server, "viz.bullet", `{}`),
+				ExpectError: regexp.MustCompile("use widget_bullet for viz.bullet"),
+			},
+			// Test: Configurations must be JSON objects
+			{
+				Config:      testAccNewRelicOneDashboardRawWidgetConfigOffline(server, "viz.gauge", `[]`),
+				ExpectError: regexp.MustCompile("to be a JSON object"),
+			},
+			// Test: Update
+			{
+				Config: testAccNewRelicOneDashboardRawWidgetConfigOffline(server, "custom.sparkline", `{ "text": "# Hello" }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_raw.0.visualization_id", "custom.sparkline"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_raw.0.configuration", `{"text":"# Hello"}`),
+				),
+			},
+			// Test: Import
+			{
+				Config:            testAccNewRelicOneDashboardRawWidgetConfigOffline(server, "custom.sparkline", `{ "text": "# Hello" }`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"page.0.widget_raw.0.configuration", // defaults are only left out when configured
+				},
+			},
+		},
+	})
+}
+
+func testAccNewRelicOneDashboardRawWidgetConfigOffline(server *testFakeServer, visualization string, configuration string) string {
+	return server.providerConfig() + fmt.Sprintf(`
+resource "newrelic_one_dashboard" "foo" {
+  name = "tf-test-dashboard"
+
+  page {
+    name = "tf-test-page"
+
+    widget_line {
+      title  = "Throughput"
+      row    = 1
+      column = 1
+
+      nrql_query {
+        account_id = %[1]d
+        query      = "FROM Transaction SELECT rate(count(*), 1 minute) TIMESERIES"
+      }
+    }
+
+    widget_raw {
+      title            = "Raw"
+      row              = 1
+      column           = 5
+      visualization_id = "%[2]s"
+
+      configuration = <<EOT
+%[3]s
+EOT
+    }
+  }
+}
+`, testFakeAccountID, visualization, configuration)
+}
+
+func TestAccNewRelicOneDashboard_LinkedEntitiesOffline(t *testing.T) {
+	resourceName := "newrelic_one_dashboard.foo"
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicOneDashboardLinkedEntitiesConfigOffline(server, true, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_bar.0.filter_current_dashboard", "true"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_bar.0.linked_entity_guids.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_table.0.filter_current_dashboard", "false"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_table.0.linked_entity_guids.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "page.0.widget_table.0.linked_entity_guids.0", "newrelic_one_dashboard.bar", "guid"),
+				),
+			},
+			// Test: Update with a new page
+			{
+				Config: testAccNewRelicOneDashboardLinkedEntitiesConfigOffline(server, true, fmt.Sprintf(`
+  page {
+    name = "tf-test-page-2"
+
+    widget_pie {
+      title                    = "Transactions"
+      row                      = 1
+      column                   = 1
+      filter_current_dashboard = true
+
+      nrql_query {
+        account_id = %d
+        query      = "FROM Transaction SELECT count(*) FACET name"
+      }
+    }
+  }
+`, testFakeAccountID)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_bar.0.filter_current_dashboard", "true"),
+					resource.TestCheckResourceAttrSet(resourceName, "page.1.guid"),
+					resource.TestCheckResourceAttr(resourceName, "page.1.widget_pie.0.filter_current_dashboard", "true"),
+					resource.TestCheckResourceAttr(resourceName, "page.1.widget_pie.0.linked_entity_guids.#", "0"),
+				),
+			},
+			// Test: Remove the filter
+			{
+				Config: testAccNewRelicOneDashboardLinkedEntitiesConfigOffline(server, false, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "page.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_bar.0.filter_current_dashboard", "false"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            testAccNewRelicOneDashboardLinkedEntitiesConfigOffline(server, false, ""),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNewRelicOneDashboardLinkedEntitiesConfigOffline(server *testFakeServer, filterCurrentDashboard bool, pages string) string {
+	return server.providerConfig() + fmt.Sprintf(`
+resource "newrelic_one_dashboard" "bar" {
+  name = "tf-test-dashboard-details"
+
+  page {
+    name = "tf-test-page"
+
+    widget_markdown {
+      title  = "Details"
+      row    = 1
+      column = 1
+      text   = "# Details"
+    }
+  }
+}
+
+resource "newrelic_one_dashboard" "foo" {
+  name = "tf-test-dashboard"
+
+  page {
+    name = "tf-test-page"
+
+    widget_bar {
+      title                    = "Applications"
+      row                      = 1
+      column                   = 1
+      filter_current_dashboard = %[2]t
+
+      nrql_query {
+        account_id = %[1]d
+        query      = "FROM Transaction SELECT count(*) FACET appName"
+      }
+    }
+
+    widget_table {
+      title               = "Hosts"
+      row                 = 1
+      column              = 5
+      linked_entity_guids = [newrelic_one_dashboard.bar.guid]
+
+      nrql_query {
+        account_id = %[1]d
+        query      = "FROM Transaction SELECT count(*) FACET host"
+      }
+    }
+  }
+%[3]s}
+`, testFakeAccountID, filterCurrentDashboard, pages)
+}
+
+func TestAccNewRelicOneDashboard_VariablesOffline(t *testing.T) {
+	resourceName := "newrelic_one_dashboard.foo"
+	server := newTestFakeServer(t)
+
+	stringVariable := `
+  variable {
+    name           = "environment"
+    title          = "Environment"
+    type           = "string"
+    default_values = ["production"]
+  }
+`
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicOneDashboardVariablesConfigOffline(server, stringVariable),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "variable.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "variable.0.name", "appName"),
+					resource.TestCheckResourceAttr(resourceName, "variable.0.type", "nrql"),
+					resource.TestCheckResourceAttr(resourceName, "variable.0.is_multi_selection", "true"),
+					resource.TestCheckResourceAttr(resourceName, "variable.0.replacement_strategy", "string"),
+					resource.TestCheckResourceAttr(resourceName, "variable.0.default_values.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "variable.0.default_values.1", "api"),
+					resource.TestCheckResourceAttr(resourceName, "variable.0.nrql_query.0.account_ids.0", fmt.Sprint(testFakeAccountID)),
+					resource.TestCheckResourceAttr(resourceName, "variable.0.nrql_query.0.query", "FROM Transaction SELECT uniques(appName)"),
+					resource.TestCheckResourceAttr(resourceName, "variable.1.type", "enum"),
+					resource.TestCheckResourceAttr(resourceName, "variable.1.replacement_strategy", "default"),
+					resource.TestCheckResourceAttr(resourceName, "variable.1.item.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "variable.1.item.1.title", "Other"),
+					resource.TestCheckResourceAttr(resourceName, "variable.1.item.1.value", "Other"),
+					resource.TestCheckResourceAttr(resourceName, "variable.2.title", "Environment"),
+					resource.TestCheckResourceAttr(resourceName, "page.0.widget_line.0.nrql_query.0.query", "FROM Transaction SELECT count(*) WHERE appName IN ({{appName}}) AND transactionType = {{transactionType}} TIMESERIES"),
+				),
+			},
+			// Test: Update removes a variable
+			{
+				Config: testAccNewRelicOneDashboardVariablesConfigOffline(server, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "variable.#", "2"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            testAccNewRelicOneDashboardVariablesConfigOffline(server, ""),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Test: Variables need the values of their type
+			{
+				Config: testAccNewRelicOneDashboardVariablesConfigOffline(server, `
+  variable {
+    name = "region"
+    type = "enum"
+  }
+`),
+				ExpectError: regexp.MustCompile("variable region of type enum requires at least one `item` block"),
+			},
+			{
+				Config: testAccNewRelicOneDashboardVariablesConfigOffline(server, `
+  variable {
+    name = "region"
+    type = "string"
+
+    item {
+      value = "us"
+    }
+  }
+`),
+				ExpectError: regexp.MustCompile("`item` blocks are only supported by variables of type enum"),
+			},
+		},
+	})
+}
+
+func testAccNewRelicOneDashboardVariablesConfigOffline(server *testFakeServer, variables string) string {
+	return server.providerConfig() + fmt.Sprintf(`
+resource "newrelic_one_dashboard" "foo" {
+  name = "tf-test-dashboard"
+
+  variable {
+    name                 = "appName"
+    title                = "Application"
+    type                 = "nrql"
+    default_values       = ["web", "api"]
+    is_multi_selection   = true
+    replacement_strategy = "string"
+
+    nrql_query {
+      account_ids = [%[1]d]
+      query       = "FROM Transaction SELECT uniques(appName)"
+    }
+  }
+
+  variable {
+    name = "transactionType"
+    type = "enum"
+
+    item {
+      title = "Web"
+      value = "Web"
+    }
+
+    item {
+      title = "Other"
+      value = "Other"
+    }
+  }
+%[2]s
+  page {
+    name = "tf-test-page"
+
+    widget_line {
+      title  = "Throughput"
+      row    = 1
+      column = 1
+
+      nrql_query {
+        account_id = %[1]d
+        query      = "FROM Transaction SELECT count(*) WHERE appName IN ({{appName}}) AND transactionType = {{transactionType}} TIMESERIES"
+      }
+    }
+  }
+}
+`, testFakeAccountID, variables)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/newrelic/newrelic-client-go/pkg/entities"
 	"github.com/newrelic/newrelic-client-go/pkg/errors"
 	"github.com/newrelic/newrelic-client-go/pkg/synthetics"
 )
@@ -19,6 +20,7 @@ func resourceNewRelicSyntheticsMonitor() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: customizeDiffDefaultTags,
 		Schema: map[string]*schema.Schema{
 			"type": {
 				Type:        schema.TypeString,
@@ -93,6 +95,12 @@ func resourceNewRelicSyntheticsMonitor() *schema.Resource {
 				Optional:    true,
 				Description: "Fail the monitor check if redirected.",
 			},
+			"guid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The unique entity identifier of the monitor in New Relic.",
+			},
+			"default_tags": defaultTagsSchema(),
 		},
 	}
 }
@@ -204,6 +212,11 @@ func resourceNewRelicSyntheticsMonitorCreate(d *schema.ResourceData, meta interf
 	}
 
 	d.SetId(monitor.ID)
+
+	if err := updateDefaultTags(d, meta, syntheticsMonitorGUID(meta, d.Id())); err != nil {
+		return err
+	}
+
 	return resourceNewRelicSyntheticsMonitorRead(d, meta)
 }
 
@@ -222,7 +235,14 @@ func resourceNewRelicSyntheticsMonitorRead(d *schema.ResourceData, meta interfac
 		return err
 	}
 
-	return readSyntheticsMonitorStruct(monitor, d)
+	if err := readSyntheticsMonitorStruct(monitor, d); err != nil {
+		return err
+	}
+
+	guid := syntheticsMonitorGUID(meta, d.Id())
+	d.Set("guid", guid)
+
+	return readDefaultTags(d, meta, guid)
 }
 
 func resourceNewRelicSyntheticsMonitorUpdate(d *schema.ResourceData, meta interface{}) error {
@@ -234,6 +254,10 @@ func resourceNewRelicSyntheticsMonitorUpdate(d *schema.ResourceData, meta interf
 		return err
 	}
 
+	if err := updateDefaultTags(d, meta, syntheticsMonitorGUID(meta, d.Id())); err != nil {
+		return err
+	}
+
 	return resourceNewRelicSyntheticsMonitorRead(d, meta)
 }
 
@@ -248,3 +272,9 @@ func resourceNewRelicSyntheticsMonitorDelete(d *schema.ResourceData, meta interf
 
 	return nil
 }
+
+// syntheticsMonitorGUID returns the entity GUID of a monitor, which the
+// Synthetics REST API doesn't return.
+func syntheticsMonitorGUID(meta interface{}, monitorID string) entities.EntityGUID {
+	return entityGUID(meta.(*ProviderConfig).AccountID, "SYNTH", "MONITOR", monitorID)
+}
@@ -0,0 +1,98 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccNewRelicSyntheticsMonitor_Offline(t *testing.T) {
+	resourceName := "newrelic_synthetics_monitor.foo"
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicSyntheticsMonitorConfigOffline(server, "tf-test-monitor", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test-monitor"),
+					resource.TestCheckResourceAttr(resourceName, "frequency", "1"),
+					resource.TestCheckResourceAttr(resourceName, "validation_string", "add example validation check here"),
+				),
+			},
+			// Test: Update
+			{
+				Config: testAccNewRelicSyntheticsMonitorConfigOffline(server, "tf-test-monitor-updated", 5),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test-monitor-updated"),
+					resource.TestCheckResourceAttr(resourceName, "frequency", "5"),
+				),
+			},
+			// Test: Import
+			{
+				Config:            testAccNewRelicSyntheticsMonitorConfigOffline(server, "tf-test-monitor-updated", 5),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNewRelicSyntheticsMonitorConfigOffline(server *testFakeServer, name string, frequency int, providerSettings ...string) string {
+	return server.providerConfig(providerSettings...) + fmt.Sprintf(`
+resource "newrelic_synthetics_monitor" "foo" {
+  name      = "%s"
+  type      = "SIMPLE"
+  frequency = %d
+  status    = "DISABLED"
+  locations = ["AWS_US_EAST_1"]
+
+  uri                       = "https://example.com"
+  validation_string         = "add example validation check here"
+  verify_ssl                = true
+  bypass_head_request       = false
+  treat_redirect_as_failure = false
+}
+`, name, frequency)
+}
+
+func TestAccNewRelicSyntheticsMonitor_DefaultTagsOffline(t *testing.T) {
+	resourceName := "newrelic_synthetics_monitor.foo"
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: Apply the provider's default tags
+			{
+				Config: testAccNewRelicSyntheticsMonitorConfigOffline(server, "tf-test-monitor", 1, `
+  default_tags {
+    tags = {
+      team = "platform"
+    }
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "default_tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "default_tags.team", "platform"),
+				),
+			},
+			// Test: Remove the default tags
+			{
+				Config: testAccNewRelicSyntheticsMonitorConfigOffline(server, "tf-test-monitor", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "default_tags.%", "0"),
+				),
+			},
+		},
+	})
+}
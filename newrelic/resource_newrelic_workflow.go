@@ -0,0 +1,284 @@
+package newrelic
+
+import (
+	"errors"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	nrErrors "github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+func resourceNewRelicWorkflow() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNewRelicWorkflowCreate,
+		Read:   resourceNewRelicWorkflowRead,
+		Update: resourceNewRelicWorkflowUpdate,
+		Delete: resourceNewRelicWorkflowDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The New Relic account ID to operate on.",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The name of the workflow.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the workflow is enabled.",
+			},
+			"destinations_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the workflow sends notifications to its destinations.",
+			},
+			"enrichments_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the enrichments of the workflow are added to its notifications.",
+			},
+			"muting_rules_handling": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "NOTIFY_ALL_ISSUES",
+				ValidateFunc: validation.StringInSlice(workflowMutingRulesHandlings, false),
+				Description:  "How the workflow handles issues muted by muting rules. One of NOTIFY_ALL_ISSUES, DONT_NOTIFY_FULLY_MUTED_ISSUES or DONT_NOTIFY_FULLY_OR_PARTIALLY_MUTED_ISSUES.",
+			},
+			"issues_filter": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "The filter selecting the issues the workflow notifies about.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"filter_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the filter.",
+						},
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.NoZeroValues,
+							Description:  "The name of the filter.",
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "FILTER",
+							ValidateFunc: validation.StringInSlice(workflowFilterTypes, false),
+							Description:  "The type of the filter. One of FILTER or VIEW.",
+						},
+						"predicate": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "A condition issues must meet, e.g. on the policy IDs, tags or priority of the issue.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"attribute": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.NoZeroValues,
+										Description:  "The issue attribute to compare, e.g. labels.policyIds, accumulations.tag.team or priority.",
+									},
+									"operator": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice(workflowPredicateOperators, false),
+										Description:  "The comparison operator.",
+									},
+									"values": {
+										Type:        schema.TypeList,
+										Required:    true,
+										MinItems:    1,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Description: "The values to compare the attribute with.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"enrichments": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Data added to the notifications of the workflow.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"nrql": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Description: "The results of NRQL queries added to the notifications.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"enrichment_id": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The ID of the enrichment.",
+									},
+									"name": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.NoZeroValues,
+										Description:  "The name of the enrichment.",
+									},
+									"configuration": {
+										Type:        schema.TypeList,
+										Required:    true,
+										MinItems:    1,
+										Description: "The queries of the enrichment.",
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"query": {
+													Type:         schema.TypeString,
+													Required:     true,
+													ValidateFunc: validation.NoZeroValues,
+													Description:  "The NRQL query.",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"destination": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The notification channels the workflow sends notifications to.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"channel_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.NoZeroValues,
+							Description:  "The ID of the notification channel.",
+						},
+						"notification_triggers": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringInSlice(workflowNotificationTriggers, false)},
+							Description: "The issue events notified about. Defaults to all of them.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the notification channel.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the notification channel.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceNewRelicWorkflowCreate(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return errors.New("err: NerdGraph support not present, but required for Create")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+	input := expandWorkflowCreateInput(d)
+
+	log.Printf("[INFO] Creating New Relic workflow %s", input.Name)
+
+	created, err := createWorkflow(client, accountID, input)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(created.ID)
+
+	return resourceNewRelicWorkflowRead(d, meta)
+}
+
+func resourceNewRelicWorkflowRead(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return errors.New("err: NerdGraph support not present, but required for Read")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Reading New Relic workflow %s", d.Id())
+
+	w, err := getWorkflow(client, accountID, d.Id())
+	if err != nil {
+		if _, ok := err.(*nrErrors.NotFound); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if err := d.Set("account_id", accountID); err != nil {
+		return err
+	}
+
+	return flattenWorkflow(w, d)
+}
+
+func resourceNewRelicWorkflowUpdate(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return errors.New("err: NerdGraph support not present, but required for Update")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Updating New Relic workflow %s", d.Id())
+
+	if _, err := updateWorkflow(client, accountID, expandWorkflowUpdateInput(d)); err != nil {
+		return err
+	}
+
+	return resourceNewRelicWorkflowRead(d, meta)
+}
+
+func resourceNewRelicWorkflowDelete(d *schema.ResourceData, meta interface{}) error {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return errors.New("err: NerdGraph support not present, but required for Delete")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Deleting New Relic workflow %s", d.Id())
+
+	return deleteWorkflow(client, accountID, d.Id())
+}
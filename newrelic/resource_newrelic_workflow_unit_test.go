@@ -0,0 +1,261 @@
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccNewRelicWorkflow_Offline(t *testing.T) {
+	resourceName := "newrelic_workflow.foo"
+	server := newTestFakeServer(t)
+
+	var filterID, enrichmentID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicWorkflowConfigOffline(server, "tf-test-workflow", "CRITICAL", `
+  enrichments {
+    nrql {
+      name = "Log count"
+
+      configuration {
+        query = "SELECT count(*) FROM Log"
+      }
+    }
+  }
+
+  destination {
+    channel_id = newrelic_notification_channel.foo.id
+  }
+
+  destination {
+    channel_id            = newrelic_notification_channel.bar.id
+    notification_triggers = ["ACTIVATED", "CLOSED"]
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test-workflow"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "muting_rules_handling", "NOTIFY_ALL_ISSUES"),
+					resource.TestCheckResourceAttr(resourceName, "issues_filter.0.type", "FILTER"),
+					resource.TestCheckResourceAttr(resourceName, "issues_filter.0.predicate.#", "3"),
+					resource.TestCheckResourceAttrPair(resourceName, "issues_filter.0.predicate.0.values.0", "newrelic_alert_policy.foo", "id"),
+					resource.TestCheckResourceAttr(resourceName, "issues_filter.0.predicate.2.values.0", "CRITICAL"),
+					resource.TestCheckResourceAttr(resourceName, "enrichments.0.nrql.0.name", "Log count"),
+					resource.TestCheckResourceAttr(resourceName, "enrichments.0.nrql.0.configuration.0.query", "SELECT count(*) FROM Log"),
+					resource.TestCheckResourceAttr(resourceName, "destination.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "destination.0.name", "tf-test-webhook"),
+					resource.TestCheckResourceAttr(resourceName, "destination.0.type", "WEBHOOK"),
+					resource.TestCheckResourceAttr(resourceName, "destination.0.notification_triggers.#", "5"),
+					resource.TestCheckResourceAttr(resourceName, "destination.1.notification_triggers.#", "2"),
+					testAccCheckNewRelicWorkflowAttr(resourceName, "issues_filter.0.filter_id", &filterID),
+					testAccCheckNewRelicWorkflowAttr(resourceName, "enrichments.0.nrql.0.enrichment_id", &enrichmentID),
+				),
+			},
+			// Test: Update
+			{
+				Config: testAccNewRelicWorkflowConfigOffline(server, "tf-test-workflow-updated", "HIGH", `
+  enabled               = false
+  muting_rules_handling = "DONT_NOTIFY_FULLY_MUTED_ISSUES"
+
+  enrichments {
+    nrql {
+      name = "Log count"
+
+      configuration {
+        query = "SELECT count(*) FROM Log SINCE 1 hour ago"
+      }
+    }
+
+    nrql {
+      name = "Error count"
+
+      configuration {
+        query = "SELECT count(*) FROM TransactionError"
+      }
+    }
+  }
+
+  destination {
+    channel_id = newrelic_notification_channel.bar.id
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test-workflow-updated"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "muting_rules_handling", "DONT_NOTIFY_FULLY_MUTED_ISSUES"),
+					resource.TestCheckResourceAttr(resourceName, "issues_filter.0.predicate.2.values.0", "HIGH"),
+					resource.TestCheckResourceAttr(resourceName, "enrichments.0.nrql.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "enrichments.0.nrql.0.configuration.0.query", "SELECT count(*) FROM Log SINCE 1 hour ago"),
+					resource.TestCheckResourceAttr(resourceName, "destination.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "destination.0.name", "tf-test-email"),
+					testAccCheckNewRelicWorkflowAttr(resourceName, "issues_filter.0.filter_id", &filterID),
+					testAccCheckNewRelicWorkflowAttr(resourceName, "enrichments.0.nrql.0.enrichment_id", &enrichmentID),
+				),
+			},
+			// Test: Import
+			{
+				Config: testAccNewRelicWorkflowConfigOffline(server, "tf-test-workflow-updated", "HIGH", `
+  enabled               = false
+  muting_rules_handling = "DONT_NOTIFY_FULLY_MUTED_ISSUES"
+
+  enrichments {
+    nrql {
+      name = "Log count"
+
+      configuration {
+        query = "SELECT count(*) FROM Log SINCE 1 hour ago"
+      }
+    }
+
+    nrql {
+      name = "Error count"
+
+      configuration {
+        query = "SELECT count(*) FROM TransactionError"
+      }
+    }
+  }
+
+  destination {
+    channel_id = newrelic_notification_channel.bar.id
+  }
+`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccNewRelicWorkflow_UnknownChannelOffline(t *testing.T) {
+	server := newTestFakeServer(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		IsUnitTest:   true,
+		Providers:    server.providers(),
+		CheckDestroy: server.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: server.providerConfig() + `
+resource "newrelic_workflow" "foo" {
+  name = "tf-test-workflow"
+
+  issues_filter {
+    name = "tf-test-filter"
+  }
+
+  destination {
+    channel_id = "00000000-0000-4000-8000-000000000000"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile("workflow error: INVALID_PARAMETER: Channel 00000000-0000-4000-8000-000000000000 not found"),
+			},
+		},
+	})
+}
+
+// testAccCheckNewRelicWorkflowAttr records an attribute the first time it is
+// called, and checks that it is unchanged on later calls.
+func testAccCheckNewRelicWorkflowAttr(n string, key string, value *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		v := rs.Primary.Attributes[key]
+		if v == "" {
+			return fmt.Errorf("%s is not set", key)
+		}
+
+		if *value != "" && *value != v {
+			return fmt.Errorf("%s changed from %s to %s", key, *value, v)
+		}
+
+		*value = v
+
+		return nil
+	}
+}
+
+func testAccNewRelicWorkflowConfigOffline(server *testFakeServer, name string, priority string, settings string) string {
+	return server.providerConfig() + fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-policy"
+}
+
+resource "newrelic_notification_destination" "webhook" {
+  name = "tf-test-webhook"
+  type = "WEBHOOK"
+
+  property {
+    key   = "url"
+    value = "https://example.com/hook"
+  }
+}
+
+resource "newrelic_notification_destination" "email" {
+  name = "tf-test-email"
+  type = "EMAIL"
+
+  property {
+    key   = "email"
+    value = "foo@example.com"
+  }
+}
+
+resource "newrelic_notification_channel" "foo" {
+  name           = "tf-test-webhook"
+  type           = "WEBHOOK"
+  destination_id = newrelic_notification_destination.webhook.id
+  product        = "IINT"
+}
+
+resource "newrelic_notification_channel" "bar" {
+  name           = "tf-test-email"
+  type           = "EMAIL"
+  destination_id = newrelic_notification_destination.email.id
+  product        = "IINT"
+}
+
+resource "newrelic_workflow" "foo" {
+  name = "%s"
+
+  issues_filter {
+    name = "tf-test-filter"
+
+    predicate {
+      attribute = "labels.policyIds"
+      operator  = "EXACTLY_MATCHES"
+      values    = [newrelic_alert_policy.foo.id]
+    }
+
+    predicate {
+      attribute = "accumulations.tag.team"
+      operator  = "EXACTLY_MATCHES"
+      values    = ["platform"]
+    }
+
+    predicate {
+      attribute = "priority"
+      operator  = "EQUAL"
+      values    = ["%s"]
+    }
+  }
+%s}
+`, name, priority, settings)
+}
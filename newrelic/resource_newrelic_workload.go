@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/newrelic/newrelic-client-go/pkg/entities"
 	"github.com/newrelic/newrelic-client-go/pkg/errors"
 )
 
@@ -19,6 +20,7 @@ func resourceNewRelicWorkload() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: customizeDiffDefaultTags,
 		Schema: map[string]*schema.Schema{
 			"account_id": {
 				Type:        schema.TypeInt,
@@ -70,6 +72,7 @@ func resourceNewRelicWorkload() *schema.Resource {
 				Computed:    true,
 				Description: "The unique entity identifier of the workload in New Relic.",
 			},
+			"default_tags": defaultTagsSchema(),
 			"composite_entity_search_query": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -103,6 +106,11 @@ func resourceNewRelicWorkloadCreate(d *schema.ResourceData, meta interface{}) er
 	}
 
 	d.SetId(ids.String())
+
+	if err := updateDefaultTags(d, meta, entities.EntityGUID(ids.GUID)); err != nil {
+		return err
+	}
+
 	return resourceNewRelicWorkloadRead(d, meta)
 }
 
@@ -124,7 +132,11 @@ func resourceNewRelicWorkloadRead(d *schema.ResourceData, meta interface{}) erro
 		return err
 	}
 
-	return flattenWorkload(workload, d)
+	if err := flattenWorkload(workload, d); err != nil {
+		return err
+	}
+
+	return readDefaultTags(d, meta, entities.EntityGUID(ids.GUID))
 }
 
 func resourceNewRelicWorkloadUpdate(d *schema.ResourceData, meta interface{}) error {
@@ -143,6 +155,10 @@ func resourceNewRelicWorkloadUpdate(d *schema.ResourceData, meta interface{}) er
 		return err
 	}
 
+	if err := updateDefaultTags(d, meta, entities.EntityGUID(ids.GUID)); err != nil {
+		return err
+	}
+
 	d.SetId(ids.String())
 
 	return resourceNewRelicWorkloadRead(d, meta)
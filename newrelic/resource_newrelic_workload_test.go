@@ -45,6 +45,37 @@ func TestAccNewRelicWorkload_Basic(t *testing.T) {
 	})
 }
 
+func TestAccNewRelicWorkload_DefaultTags(t *testing.T) {
+	resourceName := "newrelic_workload.foo"
+	rName := acctest.RandString(5)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicWorkloadDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create with default tags
+			{
+				Config: testAccNewRelicWorkloadConfigDefaultTags(rName, "platform"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicWorkloadExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "default_tags.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "default_tags.team", "platform"),
+					resource.TestCheckResourceAttr(resourceName, "default_tags.managed_by", "terraform"),
+				),
+			},
+			// Test: Update default tags
+			{
+				Config: testAccNewRelicWorkloadConfigDefaultTags(rName, "observability"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicWorkloadExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "default_tags.team", "observability"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccNewRelicWorkload_EntitiesOnly(t *testing.T) {
 	resourceName := "newrelic_workload.foo"
 	rName := acctest.RandString(5)
@@ -240,3 +271,23 @@ resource "newrelic_workload" "foo" {
 }
 `, testAccountID, name)
 }
+
+func testAccNewRelicWorkloadConfigDefaultTags(name string, team string) string {
+	return fmt.Sprintf(`
+provider "newrelic" {
+	default_tags {
+		tags = {
+			team       = "%[3]s"
+			managed_by = "terraform"
+		}
+	}
+}
+
+resource "newrelic_workload" "foo" {
+	name = "%[2]s"
+	account_id = %[1]d
+
+	scope_account_ids =  [%[1]d]
+}
+`, testAccountID, name, team)
+}
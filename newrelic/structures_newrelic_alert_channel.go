@@ -3,7 +3,10 @@ package newrelic
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/newrelic/newrelic-client-go/pkg/alerts"
@@ -15,6 +18,18 @@ func expandAlertChannel(d *schema.ResourceData) (*alerts.Channel, error) {
 		Type: alerts.ChannelType(d.Get("type").(string)),
 	}
 
+	if webhookType, ok := alertChannelWebhookTypes[string(channel.Type)]; ok {
+		config, ok := d.GetOk(string(channel.Type))
+		if !ok {
+			return nil, fmt.Errorf("alert channel of type %s requires a %s block", channel.Type, channel.Type)
+		}
+
+		channel.Type = alerts.ChannelTypes.Webhook
+		channel.Configuration = webhookType.expand(config.([]interface{})[0].(map[string]interface{}))
+
+		return &channel, nil
+	}
+
 	config, configOk := d.GetOk("config")
 
 	if !configOk {
@@ -96,22 +111,12 @@ func expandAlertChannelConfiguration(cfg map[string]interface{}) (*alerts.Channe
 		config.IncludeJSONAttachment = includeJSONAttachment.(string)
 	}
 
-	if payload, ok := cfg["payload"]; ok {
-		p := payload.(map[string]interface{})
-		config.Payload = p
+	payload, err := expandAlertChannelPayload(cfg)
+	if err != nil {
+		return nil, err
 	}
 
-	if payload, ok := cfg["payload_string"]; ok && payload != "" {
-		s := []byte(payload.(string))
-		var p map[string]interface{}
-		err := json.Unmarshal(s, &p)
-
-		if err != nil {
-			return nil, err
-		}
-
-		config.Payload = p
-	}
+	config.Payload = payload
 
 	if payloadType, ok := cfg["payload_type"]; ok {
 		config.PayloadType = payloadType.(string)
@@ -152,6 +157,136 @@ func expandAlertChannelConfiguration(cfg map[string]interface{}) (*alerts.Channe
 	return &config, nil
 }
 
+// expandAlertChannelPayload returns the payload of a webhook channel from
+// whichever of payload, payload_string or payload_json is set.
+func expandAlertChannelPayload(cfg map[string]interface{}) (map[string]interface{}, error) {
+	for _, k := range []string{"payload_string", "payload_json"} {
+		if payload, ok := cfg[k]; ok && payload != "" {
+			var p map[string]interface{}
+			if err := json.Unmarshal([]byte(payload.(string)), &p); err != nil {
+				return nil, err
+			}
+
+			return p, nil
+		}
+	}
+
+	if payload, ok := cfg["payload"]; ok {
+		return payload.(map[string]interface{}), nil
+	}
+
+	return nil, nil
+}
+
+// expandAlertChannelUpdateInput returns the input of the NerdGraph update of an
+// alert channel from its REST configuration.
+//
+//nolint:gocyclo
+func expandAlertChannelUpdateInput(channel *alerts.Channel) (*alertChannelUpdateInput, error) {
+	c := channel.Configuration
+	input := alertChannelUpdateInput{}
+
+	switch channel.Type {
+	case alerts.ChannelTypes.Email:
+		input.Email = &alertEmailChannelUpdateInput{
+			Name:        channel.Name,
+			Emails:      splitAlertChannelList(c.Recipients),
+			IncludeJSON: c.IncludeJSONAttachment == "1" || c.IncludeJSONAttachment == "true",
+		}
+	case alerts.ChannelTypes.OpsGenie:
+		input.OpsGenie = &alertOpsGenieChannelUpdateInput{
+			Name:             channel.Name,
+			APIKey:           c.APIKey,
+			DataCenterRegion: c.Region,
+			Recipients:       splitAlertChannelList(c.Recipients),
+			Tags:             splitAlertChannelList(c.Tags),
+			Teams:            splitAlertChannelList(c.Teams),
+		}
+	case alerts.ChannelTypes.PagerDuty:
+		input.PagerDuty = &alertPagerDutyChannelUpdateInput{
+			Name:   channel.Name,
+			APIKey: c.ServiceKey,
+		}
+	case alerts.ChannelTypes.Slack:
+		input.Slack = &alertSlackChannelUpdateInput{
+			Name:        channel.Name,
+			URL:         c.URL,
+			TeamChannel: c.Channel,
+		}
+	case alerts.ChannelTypes.VictorOps:
+		input.VictorOps = &alertVictorOpsChannelUpdateInput{
+			Name:     channel.Name,
+			Key:      c.Key,
+			RouteKey: c.RouteKey,
+		}
+	case alerts.ChannelTypes.Webhook:
+		webhook := alertWebhookChannelUpdateInput{
+			Name:              channel.Name,
+			BaseURL:           c.BaseURL,
+			CustomHTTPHeaders: []alertWebhookCustomHeaderUpdateInput{},
+		}
+
+		if c.AuthUsername != "" {
+			webhook.BasicAuth = &alertWebhookBasicAuthUpdateInput{
+				Username: c.AuthUsername,
+				Password: c.AuthPassword,
+			}
+		}
+
+		names := make([]string, 0, len(c.Headers))
+		for name := range c.Headers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			value, ok := c.Headers[name].(string)
+			if !ok {
+				v, err := json.Marshal(c.Headers[name])
+				if err != nil {
+					return nil, err
+				}
+
+				value = string(v)
+			}
+
+			webhook.CustomHTTPHeaders = append(webhook.CustomHTTPHeaders, alertWebhookCustomHeaderUpdateInput{Name: name, Value: value})
+		}
+
+		if len(c.Payload) > 0 {
+			body, err := json.Marshal(c.Payload)
+			if err != nil {
+				return nil, err
+			}
+
+			webhook.CustomPayloadBody = string(body)
+			webhook.CustomPayloadType = "JSON"
+			if c.PayloadType == "application/x-www-form-urlencoded" {
+				webhook.CustomPayloadType = "FORM"
+			}
+		}
+
+		input.Webhook = &webhook
+	default:
+		return nil, fmt.Errorf("alert channels of type %s cannot be updated", channel.Type)
+	}
+
+	return &input, nil
+}
+
+// splitAlertChannelList returns the values of a comma separated list.
+func splitAlertChannelList(list string) []string {
+	values := []string{}
+
+	for _, v := range strings.Split(list, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
 func expandAlertChannelIDs(channelIDs []interface{}) []int {
 	ids := make([]int, len(channelIDs))
 
@@ -171,7 +306,20 @@ func flattenAlertChannelDataSource(channel *alerts.Channel, d *schema.ResourceDa
 
 func flattenAlertChannel(channel *alerts.Channel, d *schema.ResourceData) error {
 	d.Set("name", channel.Name)
-	d.Set("type", channel.Type)
+
+	// A webhook created for one of the types the REST API has no type for
+	// cannot be told apart from other webhooks, so it keeps the type it was
+	// configured or imported with.
+	channelType := d.Get("type").(string)
+	if webhookType, ok := alertChannelWebhookTypes[channelType]; ok && channel.Type == alerts.ChannelTypes.Webhook {
+		if err := d.Set(channelType, []interface{}{webhookType.flatten(&channel.Configuration, d)}); err != nil {
+			return err
+		}
+
+		return d.Set("config", nil)
+	}
+
+	d.Set("type", string(channel.Type))
 
 	config, err := flattenAlertChannelConfiguration(&channel.Configuration, d)
 	if err != nil {
@@ -267,6 +415,14 @@ func flattenAlertChannelConfiguration(c *alerts.ChannelConfiguration, d *schema.
 		}
 
 		configResult["payload_string"] = string(h)
+	} else if _, ok := d.GetOk("config.0.payload_json"); ok {
+		h, err := json.Marshal(c.Payload)
+
+		if err != nil {
+			return nil, err
+		}
+
+		configResult["payload_json"] = string(h)
 	}
 
 	return []interface{}{configResult}, nil
@@ -279,3 +435,91 @@ func validateChannelConfiguration(config alerts.ChannelConfiguration) error {
 
 	return nil
 }
+
+// alertChannelWebhookType is a channel type the REST API has no type for,
+// which is created as a webhook with the payload the receiving service
+// expects.
+type alertChannelWebhookType struct {
+	expand  func(cfg map[string]interface{}) alerts.ChannelConfiguration
+	flatten func(c *alerts.ChannelConfiguration, d *schema.ResourceData) map[string]interface{}
+}
+
+var alertChannelWebhookTypes = map[string]alertChannelWebhookType{
+	"teams": {
+		expand: func(cfg map[string]interface{}) alerts.ChannelConfiguration {
+			return alerts.ChannelConfiguration{
+				BaseURL:     cfg["url"].(string),
+				PayloadType: "application/json",
+				Payload: map[string]interface{}{
+					"@type":      "MessageCard",
+					"@context":   "https://schema.org/extensions",
+					"summary":    "$SEVERITY: $CONDITION_NAME",
+					"title":      "$SEVERITY: $CONDITION_NAME",
+					"text":       "$DETAILS",
+					"themeColor": "D63333",
+					"sections": []interface{}{
+						map[string]interface{}{
+							"facts": []interface{}{
+								map[string]interface{}{"name": "Policy", "value": "$POLICY_NAME"},
+								map[string]interface{}{"name": "State", "value": "$CURRENT_STATE"},
+							},
+						},
+					},
+					"potentialAction": []interface{}{
+						map[string]interface{}{
+							"@type":   "OpenUri",
+							"name":    "View incident",
+							"targets": []interface{}{map[string]interface{}{"os": "default", "uri": "$INCIDENT_URL"}},
+						},
+					},
+				},
+			}
+		},
+		flatten: func(c *alerts.ChannelConfiguration, d *schema.ResourceData) map[string]interface{} {
+			return map[string]interface{}{
+				"url": alertChannelSecret(c.BaseURL, d, "teams.0.url"),
+			}
+		},
+	},
+	"xmatters": {
+		expand: func(cfg map[string]interface{}) alerts.ChannelConfiguration {
+			return alerts.ChannelConfiguration{
+				BaseURL:      cfg["url"].(string),
+				AuthUsername: cfg["username"].(string),
+				AuthPassword: cfg["password"].(string),
+				PayloadType:  "application/json",
+				Payload: map[string]interface{}{
+					"account_id":     "$ACCOUNT_ID",
+					"condition_name": "$CONDITION_NAME",
+					"current_state":  "$CURRENT_STATE",
+					"details":        "$DETAILS",
+					"event_type":     "$EVENT_TYPE",
+					"incident_id":    "$INCIDENT_ID",
+					"incident_url":   "$INCIDENT_URL",
+					"policy_name":    "$POLICY_NAME",
+					"severity":       "$SEVERITY",
+					"timestamp":      "$TIMESTAMP",
+					"recipients":     cfg["recipients"].(string),
+				},
+			}
+		},
+		flatten: func(c *alerts.ChannelConfiguration, d *schema.ResourceData) map[string]interface{} {
+			return map[string]interface{}{
+				"url":        alertChannelSecret(c.BaseURL, d, "xmatters.0.url"),
+				"username":   c.AuthUsername,
+				"password":   alertChannelSecret(c.AuthPassword, d, "xmatters.0.password"),
+				"recipients": c.Payload["recipients"],
+			}
+		},
+	},
+}
+
+// alertChannelSecret keeps the configured value of a secret the API does not
+// return.
+func alertChannelSecret(value string, d *schema.ResourceData, key string) string {
+	if value != "" {
+		return value
+	}
+
+	return d.Get(key).(string)
+}
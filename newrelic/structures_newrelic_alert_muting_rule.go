@@ -1,11 +1,14 @@
 package newrelic
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/newrelic/newrelic-client-go/pkg/alerts"
 )
 
-func expandMutingRuleCreateInput(d *schema.ResourceData) alerts.MutingRuleCreateInput {
+func expandMutingRuleCreateInput(d *schema.ResourceData) (alerts.MutingRuleCreateInput, error) {
 	createInput := alerts.MutingRuleCreateInput{
 		Enabled:     d.Get("enabled").(bool),
 		Name:        d.Get("name").(string),
@@ -16,11 +19,22 @@ func expandMutingRuleCreateInput(d *schema.ResourceData) alerts.MutingRuleCreate
 		createInput.Condition = expandMutingRuleConditionGroup(e.([]interface{})[0].(map[string]interface{}))
 	}
 
-	return createInput
+	if e, ok := d.GetOk("schedule"); ok {
+		schedule, err := expandMutingRuleCreateSchedule(e.([]interface{})[0].(map[string]interface{}))
+		if err != nil {
+			return alerts.MutingRuleCreateInput{}, err
+		}
+
+		createInput.Schedule = schedule
+	}
+
+	return createInput, nil
 }
 
-func expandMutingRuleUpdateInput(d *schema.ResourceData) alerts.MutingRuleUpdateInput {
-	updateInput := alerts.MutingRuleUpdateInput{
+// expandMutingRuleUpdateInput expands a muting rule update. A removed
+// schedule is sent as null, which removes it from the rule.
+func expandMutingRuleUpdateInput(d *schema.ResourceData) (*mutingRuleUpdateInput, error) {
+	updateInput := mutingRuleUpdateInput{
 		Enabled:     d.Get("enabled").(bool),
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
@@ -32,7 +46,122 @@ func expandMutingRuleUpdateInput(d *schema.ResourceData) alerts.MutingRuleUpdate
 		updateInput.Condition = &x
 	}
 
-	return updateInput
+	if e, ok := d.GetOk("schedule"); ok {
+		schedule, err := expandMutingRuleUpdateSchedule(e.([]interface{})[0].(map[string]interface{}))
+		if err != nil {
+			return nil, err
+		}
+
+		updateInput.Schedule = schedule
+	}
+
+	return &updateInput, nil
+}
+
+func expandMutingRuleCreateSchedule(cfg map[string]interface{}) (*alerts.MutingRuleScheduleCreateInput, error) {
+	schedule := alerts.MutingRuleScheduleCreateInput{
+		TimeZone: cfg["time_zone"].(string),
+	}
+
+	var err error
+
+	if schedule.StartTime, err = expandMutingRuleNaiveDateTime(cfg, "start_time"); err != nil {
+		return nil, err
+	}
+
+	if schedule.EndTime, err = expandMutingRuleNaiveDateTime(cfg, "end_time"); err != nil {
+		return nil, err
+	}
+
+	if schedule.EndRepeat, err = expandMutingRuleNaiveDateTime(cfg, "end_repeat"); err != nil {
+		return nil, err
+	}
+
+	schedule.Repeat = expandMutingRuleScheduleRepeat(cfg)
+	schedule.RepeatCount = expandMutingRuleScheduleRepeatCount(cfg)
+	schedule.WeeklyRepeatDays = expandMutingRuleWeeklyRepeatDays(cfg)
+
+	return &schedule, nil
+}
+
+// expandMutingRuleUpdateSchedule expands the schedule of a muting rule update,
+// where the fields left out of the schedule block are null.
+func expandMutingRuleUpdateSchedule(cfg map[string]interface{}) (*mutingRuleScheduleUpdateInput, error) {
+	schedule := mutingRuleScheduleUpdateInput{
+		TimeZone: cfg["time_zone"].(string),
+	}
+
+	var err error
+
+	if schedule.StartTime, err = expandMutingRuleNaiveDateTime(cfg, "start_time"); err != nil {
+		return nil, err
+	}
+
+	if schedule.EndTime, err = expandMutingRuleNaiveDateTime(cfg, "end_time"); err != nil {
+		return nil, err
+	}
+
+	if schedule.EndRepeat, err = expandMutingRuleNaiveDateTime(cfg, "end_repeat"); err != nil {
+		return nil, err
+	}
+
+	schedule.Repeat = expandMutingRuleScheduleRepeat(cfg)
+	schedule.RepeatCount = expandMutingRuleScheduleRepeatCount(cfg)
+	schedule.WeeklyRepeatDays = expandMutingRuleWeeklyRepeatDays(cfg)
+
+	return &schedule, nil
+}
+
+func expandMutingRuleNaiveDateTime(cfg map[string]interface{}, key string) (*alerts.NaiveDateTime, error) {
+	v, ok := cfg[key]
+	if !ok || v.(string) == "" {
+		return nil, nil
+	}
+
+	// Timestamps without an offset are parsed as UTC, which is what NaiveDateTime expects.
+	t, err := time.Parse(naiveDateTimeFormat, v.(string))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", key, err)
+	}
+
+	return &alerts.NaiveDateTime{Time: t}, nil
+}
+
+func expandMutingRuleScheduleRepeat(cfg map[string]interface{}) *alerts.MutingRuleScheduleRepeat {
+	if v, ok := cfg["repeat"]; ok && v.(string) != "" {
+		repeat := alerts.MutingRuleScheduleRepeat(v.(string))
+		return &repeat
+	}
+
+	return nil
+}
+
+func expandMutingRuleScheduleRepeatCount(cfg map[string]interface{}) *int {
+	if v, ok := cfg["repeat_count"]; ok && v.(int) > 0 {
+		repeatCount := v.(int)
+		return &repeatCount
+	}
+
+	return nil
+}
+
+func expandMutingRuleWeeklyRepeatDays(cfg map[string]interface{}) *[]alerts.DayOfWeek {
+	v, ok := cfg["weekly_repeat_days"]
+	if !ok {
+		return nil
+	}
+
+	days := expandStringSet(v.(*schema.Set))
+	if len(days) == 0 {
+		return nil
+	}
+
+	weeklyRepeatDays := make([]alerts.DayOfWeek, len(days))
+	for i, day := range days {
+		weeklyRepeatDays[i] = alerts.DayOfWeek(day)
+	}
+
+	return &weeklyRepeatDays
 }
 
 func expandMutingRuleConditionGroup(cfg map[string]interface{}) alerts.MutingRuleConditionGroup {
@@ -97,9 +226,54 @@ func flattenMutingRule(mutingRule *alerts.MutingRule, d *schema.ResourceData) er
 	d.Set("description", mutingRule.Description)
 	d.Set("name", mutingRule.Name)
 
+	if err := d.Set("schedule", flattenMutingRuleSchedule(mutingRule.Schedule)); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+func flattenMutingRuleSchedule(in *alerts.MutingRuleSchedule) []map[string]interface{} {
+	if in == nil {
+		return nil
+	}
+
+	schedule := map[string]interface{}{
+		"time_zone": in.TimeZone,
+	}
+
+	if in.StartTime != nil {
+		schedule["start_time"] = in.StartTime.Format(naiveDateTimeFormat)
+	}
+
+	if in.EndTime != nil {
+		schedule["end_time"] = in.EndTime.Format(naiveDateTimeFormat)
+	}
+
+	if in.Repeat != nil {
+		schedule["repeat"] = string(*in.Repeat)
+	}
+
+	if in.EndRepeat != nil {
+		schedule["end_repeat"] = in.EndRepeat.Format(naiveDateTimeFormat)
+	}
+
+	if in.RepeatCount != nil {
+		schedule["repeat_count"] = *in.RepeatCount
+	}
+
+	if in.WeeklyRepeatDays != nil {
+		days := make([]interface{}, len(*in.WeeklyRepeatDays))
+		for i, day := range *in.WeeklyRepeatDays {
+			days[i] = string(day)
+		}
+
+		schedule["weekly_repeat_days"] = days
+	}
+
+	return []map[string]interface{}{schedule}
+}
+
 func flattenMutingRuleConditionGroup(in alerts.MutingRuleConditionGroup, configuredCondition []interface{}, ok bool) []map[string]interface{} {
 
 	condition := []map[string]interface{}{
@@ -0,0 +1,120 @@
+// +build unit
+
+package newrelic
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandMutingRuleCreateSchedule(t *testing.T) {
+	cfg := map[string]interface{}{
+		"start_time":         "2021-01-28T15:30:00",
+		"end_time":           "2021-01-28T16:30:00",
+		"time_zone":          "America/Los_Angeles",
+		"repeat":             "WEEKLY",
+		"end_repeat":         "2021-06-28T00:00:00",
+		"repeat_count":       0,
+		"weekly_repeat_days": schema.NewSet(schema.HashString, []interface{}{"MONDAY"}),
+	}
+
+	schedule, err := expandMutingRuleCreateSchedule(cfg)
+	require.NoError(t, err)
+
+	assert.Equal(t, "America/Los_Angeles", schedule.TimeZone)
+	assert.Equal(t, time.Date(2021, 1, 28, 15, 30, 0, 0, time.UTC), schedule.StartTime.Time)
+	assert.Equal(t, time.Date(2021, 1, 28, 16, 30, 0, 0, time.UTC), schedule.EndTime.Time)
+	assert.Equal(t, time.Date(2021, 6, 28, 0, 0, 0, 0, time.UTC), schedule.EndRepeat.Time)
+	assert.Equal(t, alerts.MutingRuleScheduleRepeatTypes.WEEKLY, *schedule.Repeat)
+	assert.Nil(t, schedule.RepeatCount)
+	assert.Equal(t, []alerts.DayOfWeek{alerts.DayOfWeekTypes.MONDAY}, *schedule.WeeklyRepeatDays)
+
+	// The NerdGraph input must not carry a time zone offset.
+	startTime, err := schedule.StartTime.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `"2021-01-28T15:30:00"`, string(startTime))
+}
+
+func TestExpandMutingRuleUpdateInput_RemovedSchedule(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceNewRelicAlertMutingRule().Schema, map[string]interface{}{
+		"name":    "tf-test-muting-rule",
+		"enabled": true,
+	})
+
+	updateInput, err := expandMutingRuleUpdateInput(d)
+	require.NoError(t, err)
+
+	// A null schedule removes the schedule of the rule.
+	b, err := json.Marshal(updateInput)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"schedule":null`)
+}
+
+func TestExpandMutingRuleUpdateSchedule_RemovedFields(t *testing.T) {
+	cfg := map[string]interface{}{
+		"start_time":         "2021-01-28T15:30:00",
+		"end_time":           "",
+		"time_zone":          "America/Los_Angeles",
+		"repeat":             "",
+		"end_repeat":         "",
+		"repeat_count":       0,
+		"weekly_repeat_days": schema.NewSet(schema.HashString, []interface{}{}),
+	}
+
+	schedule, err := expandMutingRuleUpdateSchedule(cfg)
+	require.NoError(t, err)
+
+	// The fields left out are null, which clears them.
+	b, err := json.Marshal(schedule)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"startTime": "2021-01-28T15:30:00",
+		"endTime": null,
+		"timeZone": "America/Los_Angeles",
+		"repeat": null,
+		"endRepeat": null,
+		"repeatCount": null,
+		"weeklyRepeatDays": null
+	}`, string(b))
+}
+
+func TestExpandMutingRuleCreateSchedule_InvalidTime(t *testing.T) {
+	cfg := map[string]interface{}{
+		"start_time": "yesterday",
+		"time_zone":  "UTC",
+	}
+
+	_, err := expandMutingRuleCreateSchedule(cfg)
+	require.Error(t, err)
+}
+
+func TestFlattenMutingRuleSchedule(t *testing.T) {
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+
+	start := time.Date(2021, 1, 28, 15, 30, 0, 0, loc)
+	repeat := alerts.MutingRuleScheduleRepeatTypes.DAILY
+	repeatCount := 5
+
+	flattened := flattenMutingRuleSchedule(&alerts.MutingRuleSchedule{
+		StartTime:   &start,
+		TimeZone:    "America/Los_Angeles",
+		Repeat:      &repeat,
+		RepeatCount: &repeatCount,
+	})
+
+	require.Len(t, flattened, 1)
+	assert.Equal(t, "2021-01-28T15:30:00", flattened[0]["start_time"])
+	assert.Equal(t, "America/Los_Angeles", flattened[0]["time_zone"])
+	assert.Equal(t, "DAILY", flattened[0]["repeat"])
+	assert.Equal(t, 5, flattened[0]["repeat_count"])
+	assert.NotContains(t, flattened[0], "end_time")
+
+	assert.Nil(t, flattenMutingRuleSchedule(nil))
+}
@@ -0,0 +1,115 @@
+package newrelic
+
+import (
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// alertPolicyBundleConditionData returns an inline NRQL condition as the data
+// of a newrelic_nrql_alert_condition, so that it is expanded and flattened the
+// same way as the resource.
+//
+// The attributes of a nested block left out of the configuration read as
+// zero values, so they cannot be told apart from zero values that are
+// configured. Every value is set, so that a fill_value of 0 or an
+// open_violation_on_group_overlap of false is sent.
+func alertPolicyBundleConditionData(condition map[string]interface{}) (*schema.ResourceData, error) {
+	d := resourceNewRelicNrqlAlertCondition().Data(nil)
+
+	for k, v := range condition {
+		if k == "condition_id" || v == nil {
+			continue
+		}
+
+		if err := d.Set(k, v); err != nil {
+			return nil, err
+		}
+	}
+
+	return d, nil
+}
+
+// alertPolicyBundleConditionDiff is a planned inline NRQL condition, as read
+// by the validations of newrelic_nrql_alert_condition.
+type alertPolicyBundleConditionDiff struct {
+	*schema.ResourceData
+
+	diff   *schema.ResourceDiff
+	prefix string
+}
+
+// NewValueKnown reports whether the value of an attribute of the condition is
+// known in the plan of the bundle.
+func (c alertPolicyBundleConditionDiff) NewValueKnown(key string) bool {
+	return c.diff.NewValueKnown(c.prefix + key)
+}
+
+func flattenAlertPolicyBundleCondition(accountID int, nrqlCondition *nrqlAlertCondition, condition map[string]interface{}) (map[string]interface{}, error) {
+	d, err := alertPolicyBundleConditionData(condition)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := flattenNrqlAlertCondition(accountID, nrqlCondition, d); err != nil {
+		return nil, err
+	}
+
+	d.Set("violation_time_limit_seconds", nrqlCondition.ViolationTimeLimitSeconds)
+
+	flattened := map[string]interface{}{
+		"condition_id": nrqlCondition.ID,
+	}
+
+	for k, attr := range alertPolicyBundleConditionSchema() {
+		if k != "condition_id" {
+			flattened[k] = flattenInlineValue(d.Get(k), attr)
+		}
+	}
+
+	return flattened, nil
+}
+
+// flattenInlineValue drops the attributes of nested blocks that inlineSchema
+// leaves out.
+func flattenInlineValue(v interface{}, attr *schema.Schema) interface{} {
+	elem, ok := attr.Elem.(*schema.Resource)
+	if !ok {
+		return v
+	}
+
+	items, ok := v.([]interface{})
+	if !ok {
+		return v
+	}
+
+	flattened := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		block, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		inline := make(map[string]interface{}, len(elem.Schema))
+		for k, nested := range elem.Schema {
+			if value, ok := block[k]; ok {
+				inline[k] = flattenInlineValue(value, nested)
+			}
+		}
+
+		flattened = append(flattened, inline)
+	}
+
+	return flattened
+}
+
+func sortedAlertPolicyBundleConditionNames(conditions map[string]map[string]interface{}) []string {
+	names := make([]string, 0, len(conditions))
+	for name := range conditions {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
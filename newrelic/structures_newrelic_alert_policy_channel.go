@@ -8,13 +8,14 @@ import (
 )
 
 func expandAlertPolicyChannels(d *schema.ResourceData) (*alerts.PolicyChannels, error) {
-	channelIDs := d.Get("channel_ids").([]interface{})
+	channelIDs := d.Get("channel_ids").(*schema.Set).List()
 
 	if len(channelIDs) == 0 {
 		return nil, fmt.Errorf("must provide channel_ids for resource newrelic_alert_policy_channel")
 	}
 
 	ids := expandChannelIDs(channelIDs)
+	sortIntegerSlice(ids)
 
 	policyChannels := alerts.PolicyChannels{
 		ID:         d.Get("policy_id").(int),
@@ -37,16 +38,5 @@ func expandChannelIDs(channelIDs []interface{}) []int {
 func flattenAlertPolicyChannels(d *schema.ResourceData, policyID int, channelIDs []int) error {
 	d.Set("policy_id", policyID)
 
-	_, channelIDsOk := d.GetOk("channel_ids")
-
-	if channelIDsOk && len(channelIDs) > 0 {
-		d.Set("channel_ids", channelIDs)
-	}
-
-	// Handle import (set `channel_ids` since this resource doesn't exist in state yet)
-	if !channelIDsOk {
-		d.Set("channel_ids", channelIDs)
-	}
-
-	return nil
+	return d.Set("channel_ids", channelIDs)
 }
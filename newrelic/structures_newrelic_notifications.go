@@ -0,0 +1,136 @@
+package newrelic
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func expandNotificationProperties(properties []interface{}) []notificationProperty {
+	expanded := []notificationProperty{}
+
+	for _, p := range properties {
+		property := p.(map[string]interface{})
+
+		expanded = append(expanded, notificationProperty{
+			Key:          property["key"].(string),
+			Value:        property["value"].(string),
+			Label:        property["label"].(string),
+			DisplayValue: property["display_value"].(string),
+		})
+	}
+
+	return expanded
+}
+
+func flattenNotificationProperties(properties []notificationProperty) []interface{} {
+	flattened := make([]interface{}, 0, len(properties))
+
+	for _, p := range properties {
+		flattened = append(flattened, map[string]interface{}{
+			"key":           p.Key,
+			"value":         p.Value,
+			"label":         p.Label,
+			"display_value": p.DisplayValue,
+		})
+	}
+
+	return flattened
+}
+
+func expandNotificationDestinationInput(d *schema.ResourceData) *notificationDestinationInput {
+	input := &notificationDestinationInput{
+		Name:       d.Get("name").(string),
+		Active:     d.Get("active").(bool),
+		Properties: expandNotificationProperties(d.Get("property").(*schema.Set).List()),
+	}
+
+	if d.IsNewResource() {
+		input.Type = d.Get("type").(string)
+	}
+
+	if auth, ok := d.GetOk("auth_basic"); ok {
+		basic := auth.([]interface{})[0].(map[string]interface{})
+
+		input.Auth = &notificationAuthInput{
+			Type: "BASIC",
+			Basic: &notificationBasicAuthInput{
+				User:     basic["user"].(string),
+				Password: basic["password"].(string),
+			},
+		}
+	}
+
+	if auth, ok := d.GetOk("auth_token"); ok {
+		token := auth.([]interface{})[0].(map[string]interface{})
+
+		input.Auth = &notificationAuthInput{
+			Type: "TOKEN",
+			Token: &notificationTokenAuthInput{
+				Prefix: token["prefix"].(string),
+				Token:  token["token"].(string),
+			},
+		}
+	}
+
+	return input
+}
+
+// flattenNotificationDestination keeps the password or token of the
+// destination's auth as configured, since NerdGraph does not return them.
+func flattenNotificationDestination(destination *notificationDestination, d *schema.ResourceData) error {
+	d.Set("name", destination.Name)
+	d.Set("type", destination.Type)
+	d.Set("active", destination.Active)
+
+	if err := d.Set("property", flattenNotificationProperties(destination.Properties)); err != nil {
+		return err
+	}
+
+	var basic, token []interface{}
+
+	if destination.Auth != nil {
+		switch destination.Auth.AuthType {
+		case "BASIC":
+			basic = []interface{}{map[string]interface{}{
+				"user":     destination.Auth.User,
+				"password": d.Get("auth_basic.0.password").(string),
+			}}
+		case "TOKEN":
+			token = []interface{}{map[string]interface{}{
+				"prefix": destination.Auth.Prefix,
+				"token":  d.Get("auth_token.0.token").(string),
+			}}
+		}
+	}
+
+	if err := d.Set("auth_basic", basic); err != nil {
+		return err
+	}
+
+	return d.Set("auth_token", token)
+}
+
+func expandNotificationChannelInput(d *schema.ResourceData) *notificationChannelInput {
+	input := &notificationChannelInput{
+		Name:       d.Get("name").(string),
+		Active:     d.Get("active").(bool),
+		Properties: expandNotificationProperties(d.Get("property").(*schema.Set).List()),
+	}
+
+	if d.IsNewResource() {
+		input.Type = d.Get("type").(string)
+		input.DestinationID = d.Get("destination_id").(string)
+		input.Product = d.Get("product").(string)
+	}
+
+	return input
+}
+
+func flattenNotificationChannel(channel *notificationChannel, d *schema.ResourceData) error {
+	d.Set("name", channel.Name)
+	d.Set("type", channel.Type)
+	d.Set("destination_id", channel.DestinationID)
+	d.Set("product", channel.Product)
+	d.Set("active", channel.Active)
+
+	return d.Set("property", flattenNotificationProperties(channel.Properties))
+}
@@ -29,6 +29,17 @@ var (
 		"static":     &alerts.AlertsFillOptionTypes.STATIC,
 	}
 
+	// old:new
+	violationTimeLimitSecondsMap = map[string]int{
+		"ONE_HOUR":          3600,
+		"TWO_HOURS":         7200,
+		"FOUR_HOURS":        14400,
+		"EIGHT_HOURS":       28800,
+		"TWELVE_HOURS":      43200,
+		"TWENTY_FOUR_HOURS": 86400,
+		"THIRTY_DAYS":       2592000,
+	}
+
 	// new:old
 	fillOptionMapNewOld = map[alerts.AlertsFillOption]string{
 		alerts.AlertsFillOptionTypes.NONE:       "none",
@@ -37,13 +48,127 @@ var (
 	}
 )
 
+// migrateStateNewRelicNrqlAlertConditionV0toV1 rewrites the deprecated
+// attributes in the state to their NerdGraph equivalents: `term` blocks become
+// `critical` and `warning` blocks, `duration` becomes `threshold_duration`,
+// `time_function` becomes `threshold_occurrences`, `since_value` becomes
+// `evaluation_offset` and `violation_time_limit` becomes
+// `violation_time_limit_seconds`. Terms that can't be expressed as a single
+// critical and warning block are left as they are.
+func migrateStateNewRelicNrqlAlertConditionV0toV1(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	terms, _ := rawState["term"].([]interface{})
+	critical, _ := rawState["critical"].([]interface{})
+	warning, _ := rawState["warning"].([]interface{})
+
+	if len(terms) > 0 && len(critical) == 0 && len(warning) == 0 {
+		byPriority := map[string][]interface{}{}
+
+		for _, t := range terms {
+			term, ok := t.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			priority, _ := term["priority"].(string)
+			if priority == "" {
+				priority = "critical"
+			}
+
+			migrated := map[string]interface{}{}
+			for k, v := range term {
+				if k != "priority" {
+					migrated[k] = v
+				}
+			}
+
+			byPriority[priority] = append(byPriority[priority], migrated)
+		}
+
+		if len(byPriority["critical"]) <= 1 && len(byPriority["warning"]) <= 1 && len(byPriority["critical"])+len(byPriority["warning"]) == len(terms) {
+			critical = byPriority["critical"]
+			warning = byPriority["warning"]
+			rawState["term"] = []interface{}{}
+		}
+	}
+
+	for _, terms := range [][]interface{}{critical, warning} {
+		for _, t := range terms {
+			if term, ok := t.(map[string]interface{}); ok {
+				migrateStateNrqlConditionTermV0toV1(term)
+			}
+		}
+	}
+
+	if critical != nil {
+		rawState["critical"] = critical
+	}
+
+	if warning != nil {
+		rawState["warning"] = warning
+	}
+
+	if nrql, ok := rawState["nrql"].([]interface{}); ok && len(nrql) > 0 {
+		if query, ok := nrql[0].(map[string]interface{}); ok {
+			if sinceValue, _ := query["since_value"].(string); sinceValue != "" {
+				if v, err := strconv.Atoi(sinceValue); err == nil && stateInt(query["evaluation_offset"]) == 0 {
+					query["evaluation_offset"] = v
+					query["since_value"] = ""
+				}
+			}
+		}
+	}
+
+	if limit, _ := rawState["violation_time_limit"].(string); limit != "" && stateInt(rawState["violation_time_limit_seconds"]) == 0 {
+		if seconds, ok := violationTimeLimitSecondsMap[strings.ToUpper(limit)]; ok {
+			rawState["violation_time_limit_seconds"] = seconds
+			rawState["violation_time_limit"] = ""
+		}
+	}
+
+	return rawState, nil
+}
+
+func migrateStateNrqlConditionTermV0toV1(term map[string]interface{}) {
+	if duration := stateInt(term["duration"]); duration > 0 && stateInt(term["threshold_duration"]) == 0 {
+		term["threshold_duration"] = duration * 60
+		term["duration"] = 0
+	}
+
+	if timeFunction, _ := term["time_function"].(string); timeFunction != "" {
+		if occurrences, _ := term["threshold_occurrences"].(string); occurrences == "" {
+			if v, ok := timeFunctionMap[timeFunction]; ok {
+				term["threshold_occurrences"] = strings.ToLower(string(v))
+				term["time_function"] = ""
+			}
+		}
+	}
+}
+
+// stateInt reads a number from a raw state, where it is decoded as a float64.
+func stateInt(v interface{}) int {
+	switch n := v.(type) {
+	case float64:
+		return int(n)
+	case int:
+		return n
+	}
+
+	return 0
+}
+
 // NerdGraph
-func expandNrqlAlertConditionInput(d *schema.ResourceData) (*alerts.NrqlConditionInput, error) {
-	input := alerts.NrqlConditionInput{
-		NrqlConditionBase: alerts.NrqlConditionBase{
-			Description: d.Get("description").(string),
-			Enabled:     d.Get("enabled").(bool),
-			Name:        d.Get("name").(string),
+func expandNrqlAlertConditionInput(d *schema.ResourceData) (*nrqlConditionInput, error) {
+	input := nrqlConditionInput{
+		NrqlConditionInput: alerts.NrqlConditionInput{
+			NrqlConditionBase: alerts.NrqlConditionBase{
+				Description: d.Get("description").(string),
+				Enabled:     d.Get("enabled").(bool),
+				Name:        d.Get("name").(string),
+			},
 		},
 	}
 
@@ -105,7 +230,7 @@ func expandNrqlAlertConditionInput(d *schema.ResourceData) (*alerts.NrqlConditio
 		input.ViolationTimeLimit = alerts.NrqlConditionViolationTimeLimit(strings.ToUpper(violationTimeLimit.(string)))
 	}
 
-	nrql, err := expandNrql(d, input)
+	nrql, err := expandNrql(d, input.NrqlConditionInput)
 	if err != nil {
 		return nil, err
 	}
@@ -147,7 +272,8 @@ func expandNrql(d *schema.ResourceData, condition alerts.NrqlConditionInput) (*a
 		nrql.EvaluationOffset = sv
 	} else if evalOffset, ok := d.GetOk("nrql.0.evaluation_offset"); ok {
 		nrql.EvaluationOffset = evalOffset.(int)
-	} else {
+	} else if _, ok := d.GetOk("aggregation_method"); !ok {
+		// The aggregation method, along with its delay or timer, supersedes the evaluation offset.
 		return nil, fmt.Errorf("one of `since_value` or `evaluation_offset` must be configured for block `nrql`")
 	}
 
@@ -312,9 +438,11 @@ func expandExpiration(d *schema.ResourceData) (*alerts.AlertsNrqlConditionExpira
 }
 
 // NerdGraph
-func expandSignal(d *schema.ResourceData) (*alerts.AlertsNrqlConditionSignal, error) {
-	signal := alerts.AlertsNrqlConditionSignal{
-		FillOption: fillOptionMap[strings.ToLower(d.Get("fill_option").(string))],
+func expandSignal(d *schema.ResourceData) (*nrqlConditionSignal, error) {
+	signal := nrqlConditionSignal{
+		AlertsNrqlConditionSignal: alerts.AlertsNrqlConditionSignal{
+			FillOption: fillOptionMap[strings.ToLower(d.Get("fill_option").(string))],
+		},
 	}
 
 	// Due to the way that nulls are handled as zeros in Terraform 0.11, add another check that a 0 fill_value
@@ -331,11 +459,41 @@ func expandSignal(d *schema.ResourceData) (*alerts.AlertsNrqlConditionSignal, er
 		signal.AggregationWindow = &v
 	}
 
+	// The evaluation offset of the query is the legacy form of the CADENCE
+	// aggregation method, so a method read back from the API isn't sent with it.
+	_, hasEvaluationOffset := d.GetOk("nrql.0.evaluation_offset")
+	_, hasSinceValue := d.GetOk("nrql.0.since_value")
+
+	if aggregationMethod, ok := d.GetOk("aggregation_method"); ok && !hasEvaluationOffset && !hasSinceValue {
+		v := strings.ToUpper(aggregationMethod.(string))
+		signal.AggregationMethod = &v
+	}
+
+	// aggregation_delay is a string so that a delay of 0 can be told apart from
+	// no delay at all.
+	if aggregationDelay, ok := d.GetOk("aggregation_delay"); ok {
+		v, err := strconv.Atoi(aggregationDelay.(string))
+		if err != nil {
+			return nil, fmt.Errorf("error converting `aggregation_delay` to int: %#v", err)
+		}
+		signal.AggregationDelay = &v
+	}
+
+	if aggregationTimer, ok := d.GetOk("aggregation_timer"); ok {
+		v := aggregationTimer.(int)
+		signal.AggregationTimer = &v
+	}
+
+	if slideBy, ok := d.GetOk("slide_by"); ok {
+		v := slideBy.(int)
+		signal.SlideBy = &v
+	}
+
 	return &signal, nil
 }
 
 // NerdGraph
-func flattenNrqlAlertCondition(accountID int, condition *alerts.NrqlAlertCondition, d *schema.ResourceData) error {
+func flattenNrqlAlertCondition(accountID int, condition *nrqlAlertCondition, d *schema.ResourceData) error {
 	policyID, err := strconv.Atoi(condition.PolicyID)
 	if err != nil {
 		return err
@@ -421,7 +579,7 @@ func flattenNrqlAlertCondition(accountID int, condition *alerts.NrqlAlertConditi
 		return err
 	}
 
-	if err := flattenSignal(d, condition.Signal); err != nil {
+	if err := flattenSignal(d, condition.Signal, condition.Nrql.EvaluationOffset != 0); err != nil {
 		return err
 	}
 
@@ -450,7 +608,11 @@ func flattenExpiration(d *schema.ResourceData, expiration *alerts.AlertsNrqlCond
 }
 
 // NerdGraph
-func flattenSignal(d *schema.ResourceData, signal *alerts.AlertsNrqlConditionSignal) error {
+//
+// The aggregation method and delay returned for a condition with an evaluation
+// offset are derived from the offset, and conflict with it in the
+// configuration, so they are only read for conditions without one.
+func flattenSignal(d *schema.ResourceData, signal *nrqlConditionSignal, hasEvaluationOffset bool) error {
 	if signal == nil {
 		return nil
 	}
@@ -469,6 +631,38 @@ func flattenSignal(d *schema.ResourceData, signal *alerts.AlertsNrqlConditionSig
 		}
 	}
 
+	if !hasEvaluationOffset {
+		if signal.AggregationMethod != nil {
+			if err := d.Set("aggregation_method", strings.ToLower(*signal.AggregationMethod)); err != nil {
+				return fmt.Errorf("[DEBUG] Error setting nrql alert condition `aggregation_method`: %v", err)
+			}
+		}
+
+		// The API returns a default delay and timer for the aggregation
+		// method, which would show as a change when they are not
+		// configured, so they are only read back when they are.
+		if _, ok := d.GetOk("aggregation_delay"); ok {
+			aggregationDelay := ""
+			if signal.AggregationDelay != nil {
+				aggregationDelay = strconv.Itoa(*signal.AggregationDelay)
+			}
+
+			if err := d.Set("aggregation_delay", aggregationDelay); err != nil {
+				return fmt.Errorf("[DEBUG] Error setting nrql alert condition `aggregation_delay`: %v", err)
+			}
+		}
+	}
+
+	if _, ok := d.GetOk("aggregation_timer"); ok {
+		if err := d.Set("aggregation_timer", signal.AggregationTimer); err != nil {
+			return fmt.Errorf("[DEBUG] Error setting nrql alert condition `aggregation_timer`: %v", err)
+		}
+	}
+
+	if err := d.Set("slide_by", signal.SlideBy); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting nrql alert condition `slide_by`: %v", err)
+	}
+
 	return nil
 }
 
@@ -332,7 +332,7 @@ func TestExpandNrqlAlertConditionInput(t *testing.T) {
 				}
 
 				if tc.Expanded.Signal != nil {
-					require.Equal(t, tc.Expanded.Signal, expanded.Signal)
+					require.Equal(t, tc.Expanded.Signal, &expanded.Signal.AlertsNrqlConditionSignal)
 				}
 
 				if tc.Expanded.Expiration != nil {
@@ -447,7 +447,7 @@ func TestFlattenNrqlAlertCondition(t *testing.T) {
 		testAccountID, err := nr.GetTestAccountID()
 		require.NoError(t, err)
 
-		err = flattenNrqlAlertCondition(testAccountID, condition, d)
+		err = flattenNrqlAlertCondition(testAccountID, newNrqlAlertCondition(condition), d)
 		require.NoError(t, err)
 
 		require.Equal(t, 7654321, d.Get("policy_id").(int))
@@ -626,3 +626,49 @@ func TestExpandNrqlConditionTerm(t *testing.T) {
 	}
 
 }
+
+func TestMigrateStateNewRelicNrqlAlertConditionV0toV1(t *testing.T) {
+	rawState := map[string]interface{}{
+		"term": []interface{}{
+			map[string]interface{}{"priority": "critical", "duration": float64(5), "operator": "above", "threshold": 1.5, "time_function": "all", "threshold_duration": float64(0), "threshold_occurrences": ""},
+			map[string]interface{}{"priority": "warning", "duration": float64(10), "operator": "above", "threshold": 1.0, "time_function": "any", "threshold_duration": float64(0), "threshold_occurrences": ""},
+		},
+		"critical":                     []interface{}{},
+		"warning":                      []interface{}{},
+		"nrql":                         []interface{}{map[string]interface{}{"query": "SELECT count(*) FROM Transaction", "since_value": "3", "evaluation_offset": float64(0)}},
+		"violation_time_limit":         "one_hour",
+		"violation_time_limit_seconds": float64(0),
+	}
+
+	actual, err := migrateStateNewRelicNrqlAlertConditionV0toV1(rawState, nil)
+	require.NoError(t, err)
+
+	assert.Empty(t, actual["term"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"duration": 0, "operator": "above", "threshold": 1.5, "time_function": "", "threshold_duration": 300, "threshold_occurrences": "all"},
+	}, actual["critical"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"duration": 0, "operator": "above", "threshold": 1.0, "time_function": "", "threshold_duration": 600, "threshold_occurrences": "at_least_once"},
+	}, actual["warning"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"query": "SELECT count(*) FROM Transaction", "since_value": "", "evaluation_offset": 3},
+	}, actual["nrql"])
+	assert.Equal(t, "", actual["violation_time_limit"])
+	assert.Equal(t, 3600, actual["violation_time_limit_seconds"])
+}
+
+func TestMigrateStateNewRelicNrqlAlertConditionV0toV1_KeepsAmbiguousTerms(t *testing.T) {
+	terms := func() []interface{} {
+		return []interface{}{
+			map[string]interface{}{"priority": "critical", "threshold": 1.0, "threshold_duration": float64(120)},
+			map[string]interface{}{"priority": "critical", "threshold": 2.0, "threshold_duration": float64(120)},
+		}
+	}
+
+	actual, err := migrateStateNewRelicNrqlAlertConditionV0toV1(map[string]interface{}{"term": terms()}, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, terms(), actual["term"])
+	assert.Nil(t, actual["critical"])
+	assert.Nil(t, actual["warning"])
+}
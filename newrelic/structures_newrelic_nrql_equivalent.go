@@ -0,0 +1,222 @@
+package newrelic
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// nrqlEquivalentMetric is the NRQL query of a metric of a legacy
+// newrelic_alert_condition.
+type nrqlEquivalentMetric struct {
+	function string
+	from     string
+	where    string
+}
+
+// nrqlEquivalentMetrics are the metrics of newrelic_alert_condition with a
+// NRQL equivalent, by condition type. Metrics of the other types, e.g. key
+// transactions or mobile applications, have no event with the same data.
+var nrqlEquivalentMetrics = map[string]map[string]nrqlEquivalentMetric{
+	"apm_app_metric": {
+		"apdex":                    {function: "apdex(apm.service.apdex)", from: "Metric"},
+		"error_percentage":         {function: "percentage(count(*), WHERE error IS true)", from: "Transaction"},
+		"response_time_background": {function: "average(duration)", from: "Transaction", where: "transactionType = 'Other'"},
+		"response_time_web":        {function: "average(duration)", from: "Transaction", where: "transactionType = 'Web'"},
+		"throughput_background":    {function: "rate(count(*), 1 minute)", from: "Transaction", where: "transactionType = 'Other'"},
+		"throughput_web":           {function: "rate(count(*), 1 minute)", from: "Transaction", where: "transactionType = 'Web'"},
+	},
+	"browser_metric": {
+		"ajax_response_time":   {function: "average(timeToLoadEventStart)", from: "AjaxRequest"},
+		"ajax_throughput":      {function: "rate(count(*), 1 minute)", from: "AjaxRequest"},
+		"dom_processing":       {function: "average(domProcessingDuration)", from: "PageView"},
+		"network":              {function: "average(networkDuration)", from: "PageView"},
+		"page_rendering":       {function: "average(pageRenderingDuration)", from: "PageView"},
+		"page_view_throughput": {function: "rate(count(*), 1 minute)", from: "PageView"},
+		"request_queuing":      {function: "average(queueDuration)", from: "PageView"},
+		"total_page_load":      {function: "average(duration)", from: "PageView"},
+		"web_application":      {function: "average(backendDuration)", from: "PageView"},
+	},
+	"servers_metric": {
+		"cpu_percentage":          {function: "average(cpuPercent)", from: "SystemSample"},
+		"disk_io_percentage":      {function: "average(totalUtilizationPercent)", from: "StorageSample"},
+		"fullest_disk_percentage": {function: "max(diskUsedPercent)", from: "StorageSample"},
+		"load_average_one_minute": {function: "average(loadAverageOneMinute)", from: "SystemSample"},
+		"memory_percentage":       {function: "average(memoryUsedBytes / memoryTotalBytes) * 100", from: "SystemSample"},
+	},
+}
+
+// nrqlEquivalentValueFunctions maps the user_defined_value_function of a
+// newrelic_alert_condition to the NRQL function of a timeslice metric.
+var nrqlEquivalentValueFunctions = map[string]string{
+	"average":     "average",
+	"min":         "min",
+	"max":         "max",
+	"total":       "sum",
+	"sample_size": "count",
+}
+
+var nrqlEquivalentOperators = map[string]string{
+	"above": "above",
+	"below": "below",
+	"equal": "equals",
+}
+
+var nrqlEquivalentThresholdOccurrences = map[string]string{
+	"all": "all",
+	"any": "at_least_once",
+}
+
+type nrqlEquivalent struct {
+	query                     string
+	operator                  string
+	threshold                 float64
+	thresholdDuration         int
+	thresholdOccurrences      string
+	expirationDuration        int
+	openViolationOnExpiration bool
+}
+
+func expandNrqlEquivalent(d *schema.ResourceData) (*nrqlEquivalent, error) {
+	conditionType := d.Get("type").(string)
+
+	equivalent := &nrqlEquivalent{
+		operator:             nrqlEquivalentOperators[d.Get("operator").(string)],
+		threshold:            d.Get("threshold").(float64),
+		thresholdDuration:    d.Get("duration").(int) * 60,
+		thresholdOccurrences: nrqlEquivalentThresholdOccurrences[d.Get("time_function").(string)],
+	}
+
+	if comparison, ok := d.GetOk("comparison"); ok {
+		equivalent.operator = nrqlEquivalentOperators[comparison.(string)]
+	}
+
+	var err error
+
+	switch conditionType {
+	case "infra_metric":
+		equivalent.query, err = expandNrqlEquivalentInfraMetricQuery(d)
+	case "infra_process_running":
+		equivalent.query = nrqlEquivalentQuery("uniqueCount(processId)", "ProcessSample", "hostname", d.Get("process_where").(string), d.Get("where").(string))
+	case "infra_host_not_reporting":
+		// Hosts that stop reporting are detected by the loss of their signal,
+		// the term is never met.
+		equivalent.query = nrqlEquivalentQuery("count(*)", "SystemSample", "hostname", d.Get("where").(string))
+		equivalent.operator = "below"
+		equivalent.threshold = 1
+		equivalent.expirationDuration = equivalent.thresholdDuration
+		equivalent.openViolationOnExpiration = true
+	default:
+		equivalent.query, err = expandNrqlEquivalentMetricQuery(d)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if equivalent.operator == "" {
+		return nil, fmt.Errorf("one of `operator` or `comparison` is required for %s conditions", conditionType)
+	}
+
+	return equivalent, nil
+}
+
+func expandNrqlEquivalentMetricQuery(d *schema.ResourceData) (string, error) {
+	conditionType := d.Get("type").(string)
+	metricName := d.Get("metric").(string)
+
+	if metricName == "" {
+		return "", fmt.Errorf("`metric` is required for %s conditions", conditionType)
+	}
+
+	var metric nrqlEquivalentMetric
+
+	switch {
+	case conditionType == "apm_app_metric" && metricName == "user_defined":
+		userDefinedMetric := d.Get("user_defined_metric").(string)
+		valueFunction := d.Get("user_defined_value_function").(string)
+
+		if userDefinedMetric == "" || valueFunction == "" {
+			return "", fmt.Errorf("`user_defined_metric` and `user_defined_value_function` are required for the user_defined metric")
+		}
+
+		metric = nrqlEquivalentMetric{
+			function: fmt.Sprintf("%s(newrelic.timeslice.value)", nrqlEquivalentValueFunctions[valueFunction]),
+			from:     "Metric",
+			where:    fmt.Sprintf("metricTimesliceName = '%s'", userDefinedMetric),
+		}
+	default:
+		var ok bool
+		if metric, ok = nrqlEquivalentMetrics[conditionType][metricName]; !ok {
+			return "", fmt.Errorf("the %s metric of %s conditions has no NRQL equivalent", metricName, conditionType)
+		}
+	}
+
+	where := []string{metric.where}
+
+	// Legacy server IDs have no equivalent in infrastructure samples, so the
+	// hosts of servers_metric conditions are selected by the where filter.
+	if conditionType == "servers_metric" {
+		where = append(where, d.Get("where").(string))
+	} else if entities := expandAlertConditionEntities(d.Get("entities").([]interface{})); len(entities) > 0 {
+		where = append(where, fmt.Sprintf("appId IN (%s)", strings.Join(entities, ", ")))
+	}
+
+	facet := ""
+	if conditionType == "apm_app_metric" && d.Get("condition_scope").(string) == "instance" {
+		facet = "host"
+	}
+
+	return nrqlEquivalentQuery(metric.function, metric.from, facet, where...), nil
+}
+
+func expandNrqlEquivalentInfraMetricQuery(d *schema.ResourceData) (string, error) {
+	event := d.Get("event").(string)
+	attribute := d.Get("select").(string)
+
+	if event == "" || attribute == "" {
+		return "", fmt.Errorf("`event` and `select` are required for infra_metric conditions")
+	}
+
+	return nrqlEquivalentQuery(fmt.Sprintf("average(%s)", attribute), event, "entityName", d.Get("where").(string)), nil
+}
+
+// nrqlEquivalentQuery builds a NRQL query, combining the non-empty conditions
+// with AND.
+func nrqlEquivalentQuery(function string, from string, facet string, conditions ...string) string {
+	query := fmt.Sprintf("SELECT %s FROM %s", function, from)
+
+	var where []string
+	for _, c := range conditions {
+		if c != "" {
+			where = append(where, c)
+		}
+	}
+
+	if len(where) == 1 {
+		query += " WHERE " + where[0]
+	} else if len(where) > 1 {
+		query += " WHERE (" + strings.Join(where, ") AND (") + ")"
+	}
+
+	if facet != "" {
+		query += " FACET " + facet
+	}
+
+	return query
+}
+
+func flattenNrqlEquivalent(equivalent *nrqlEquivalent, d *schema.ResourceData) error {
+	d.Set("query", equivalent.query)
+	d.Set("value_function", "single_value")
+	d.Set("expiration_duration", equivalent.expirationDuration)
+	d.Set("open_violation_on_expiration", equivalent.openViolationOnExpiration)
+
+	return d.Set("critical", []interface{}{map[string]interface{}{
+		"operator":              equivalent.operator,
+		"threshold":             equivalent.threshold,
+		"threshold_duration":    equivalent.thresholdDuration,
+		"threshold_occurrences": equivalent.thresholdOccurrences,
+	}})
+}
@@ -1,26 +1,95 @@
 package newrelic
 
 import (
+	"encoding/json"
 	"fmt"
+	"log"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
 
 	"github.com/newrelic/newrelic-client-go/pkg/dashboards"
 	"github.com/newrelic/newrelic-client-go/pkg/entities"
 	"github.com/newrelic/newrelic-client-go/pkg/nrdb"
 )
 
+// dashboardRawWidgetVisualizations maps the widget types without a typed
+// configuration in the API to their visualization. These widgets are
+// configured with a raw configuration instead.
+var dashboardRawWidgetVisualizations = map[string]string{
+	"widget_bullet":      "viz.bullet",
+	"widget_event_feed":  "viz.event-feed",
+	"widget_funnel":      "viz.funnel",
+	"widget_heatmap":     "viz.heatmap",
+	"widget_histogram":   "viz.histogram",
+	"widget_json":        "viz.json",
+	"widget_log_table":   "logger.log-table-widget",
+	"widget_stacked_bar": "viz.stacked-bar",
+}
+
+// dashboardRawWidgetTypes returns the widget types configured with a raw
+// configuration, in alphabetical order.
+func dashboardRawWidgetTypes() []string {
+	widgetTypes := make([]string, 0, len(dashboardRawWidgetVisualizations))
+	for widgetType := range dashboardRawWidgetVisualizations {
+		widgetTypes = append(widgetTypes, widgetType)
+	}
+
+	sort.Strings(widgetTypes)
+
+	return widgetTypes
+}
+
+// dashboardRawWidgetType returns the widget type of a visualization configured
+// with a raw configuration, or "" for other visualizations.
+func dashboardRawWidgetType(visualization string) string {
+	for widgetType, v := range dashboardRawWidgetVisualizations {
+		if v == visualization {
+			return widgetType
+		}
+	}
+
+	return ""
+}
+
+// dashboardWidgetType returns the widget block of a visualization. The
+// visualizations without a dedicated block are raw widgets.
+func dashboardWidgetType(visualization string) string {
+	switch visualization {
+	case "viz.area":
+		return "widget_area"
+	case "viz.bar":
+		return "widget_bar"
+	case "viz.billboard":
+		return "widget_billboard"
+	case "viz.line":
+		return "widget_line"
+	case "viz.markdown":
+		return "widget_markdown"
+	case "viz.pie":
+		return "widget_pie"
+	case "viz.table":
+		return "widget_table"
+	}
+
+	if widgetType := dashboardRawWidgetType(visualization); widgetType != "" {
+		return widgetType
+	}
+
+	return "widget_raw"
+}
+
 // Assemble the *dashboards.DashboardInput struct.
 // Used by the newrelic_one_dashboard Create function.
-func expandDashboardInput(d *schema.ResourceData) (*dashboards.DashboardInput, error) {
+func expandDashboardInput(d *schema.ResourceData) (*dashboardInput, error) {
 	var err error
 
-	dash := dashboards.DashboardInput{
-		Name: d.Get("name").(string),
-	}
+	dash := dashboardInput{}
+	dash.Name = d.Get("name").(string)
 
-	dash.Pages, err = expandDashboardPageInput(d.Get("page").([]interface{}))
+	dash.Pages, dash.filterCurrentDashboard, err = expandDashboardPageInput(d.Get("page").([]interface{}))
 	if err != nil {
 		return nil, err
 	}
@@ -34,17 +103,62 @@ func expandDashboardInput(d *schema.ResourceData) (*dashboards.DashboardInput, e
 		dash.Description = e.(string)
 	}
 
+	dash.Variables = expandDashboardVariablesInput(d.Get("variable").([]interface{}))
+
 	return &dash, nil
 }
 
+func expandDashboardVariablesInput(variables []interface{}) []dashboardVariable {
+	expanded := make([]dashboardVariable, len(variables))
+
+	for i, v := range variables {
+		var variable dashboardVariable
+		m := v.(map[string]interface{})
+
+		variable.Name = m["name"].(string)
+		variable.Title = m["title"].(string)
+		variable.Type = strings.ToUpper(m["type"].(string))
+		variable.IsMultiSelection = m["is_multi_selection"].(bool)
+		variable.ReplacementStrategy = strings.ToUpper(m["replacement_strategy"].(string))
+
+		for _, value := range m["default_values"].([]interface{}) {
+			var item dashboardVariableDefaultItem
+			item.Value.String = value.(string)
+			variable.DefaultValues = append(variable.DefaultValues, item)
+		}
+
+		for _, it := range m["item"].([]interface{}) {
+			item := it.(map[string]interface{})
+			variable.Items = append(variable.Items, dashboardVariableEnumItem{
+				Title: item["title"].(string),
+				Value: item["value"].(string),
+			})
+		}
+
+		for _, q := range m["nrql_query"].([]interface{}) {
+			query := q.(map[string]interface{})
+			variable.NRQLQuery = &dashboardVariableNRQLQuery{
+				AccountIDs: expandIntList(query["account_ids"].([]interface{})),
+				Query:      query["query"].(string),
+			}
+		}
+
+		expanded[i] = variable
+	}
+
+	return expanded
+}
+
 // TODO: Reduce the cyclomatic complexity of this func
 // nolint:gocyclo
-func expandDashboardPageInput(pages []interface{}) ([]dashboards.DashboardPageInput, error) {
+func expandDashboardPageInput(pages []interface{}) ([]dashboards.DashboardPageInput, [][]int, error) {
 	if len(pages) < 1 {
-		return []dashboards.DashboardPageInput{}, nil
+		return []dashboards.DashboardPageInput{}, nil, nil
 	}
 
 	expanded := make([]dashboards.DashboardPageInput, len(pages))
+	// The widgets filtering the current dashboard, by page
+	filterCurrentDashboard := make([][]int, len(pages))
 
 	for i, v := range pages {
 		var page dashboards.DashboardPageInput
@@ -53,7 +167,7 @@ func expandDashboardPageInput(pages []interface{}) ([]dashboards.DashboardPageIn
 		if name, ok := p["name"]; ok {
 			page.Name = name.(string)
 		} else {
-			return nil, fmt.Errorf("name required for dashboard page")
+			return nil, nil, fmt.Errorf("name required for dashboard page")
 		}
 
 		if desc, ok := p["description"]; ok {
@@ -71,12 +185,12 @@ func expandDashboardPageInput(pages []interface{}) ([]dashboards.DashboardPageIn
 				// Get generic properties set
 				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}))
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 
 				widget.Configuration.Area, err = expandDashboardAreaWidgetConfigurationInput(v.(map[string]interface{}))
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 
 				page.Widgets = append(page.Widgets, widget)
@@ -87,12 +201,16 @@ func expandDashboardPageInput(pages []interface{}) ([]dashboards.DashboardPageIn
 				// Get generic properties set
 				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}))
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 
 				widget.Configuration.Bar, err = expandDashboardBarWidgetConfigurationInput(v.(map[string]interface{}))
 				if err != nil {
-					return nil, err
+					return nil, nil, err
+				}
+
+				if expandDashboardWidgetLinkedEntityGUIDsInput(&widget, v.(map[string]interface{})) {
+					filterCurrentDashboard[i] = append(filterCurrentDashboard[i], len(page.Widgets))
 				}
 
 				page.Widgets = append(page.Widgets, widget)
@@ -103,12 +221,12 @@ func expandDashboardPageInput(pages []interface{}) ([]dashboards.DashboardPageIn
 				// Get generic properties set
 				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}))
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 
 				widget.Configuration.Billboard, err = expandDashboardBillboardWidgetConfigurationInput(v.(map[string]interface{}))
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 
 				page.Widgets = append(page.Widgets, widget)
@@ -119,12 +237,16 @@ func expandDashboardPageInput(pages []interface{}) ([]dashboards.DashboardPageIn
 				// Get generic properties set
 				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}))
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 
 				widget.Configuration.Line, err = expandDashboardLineWidgetConfigurationInput(v.(map[string]interface{}))
 				if err != nil {
-					return nil, err
+					return nil, nil, err
+				}
+
+				if expandDashboardWidgetLinkedEntityGUIDsInput(&widget, v.(map[string]interface{})) {
+					filterCurrentDashboard[i] = append(filterCurrentDashboard[i], len(page.Widgets))
 				}
 
 				page.Widgets = append(page.Widgets, widget)
@@ -135,12 +257,12 @@ func expandDashboardPageInput(pages []interface{}) ([]dashboards.DashboardPageIn
 				// Get generic properties set
 				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}))
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 
 				widget.Configuration.Markdown, err = expandDashboardMarkdownWidgetConfigurationInput(v.(map[string]interface{}))
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 
 				page.Widgets = append(page.Widgets, widget)
@@ -151,12 +273,16 @@ func expandDashboardPageInput(pages []interface{}) ([]dashboards.DashboardPageIn
 				// Get generic properties set
 				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}))
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 
 				widget.Configuration.Pie, err = expandDashboardPieWidgetConfigurationInput(v.(map[string]interface{}))
 				if err != nil {
-					return nil, err
+					return nil, nil, err
+				}
+
+				if expandDashboardWidgetLinkedEntityGUIDsInput(&widget, v.(map[string]interface{})) {
+					filterCurrentDashboard[i] = append(filterCurrentDashboard[i], len(page.Widgets))
 				}
 
 				page.Widgets = append(page.Widgets, widget)
@@ -167,22 +293,62 @@ func expandDashboardPageInput(pages []interface{}) ([]dashboards.DashboardPageIn
 				// Get generic properties set
 				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}))
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 
 				widget.Configuration.Table, err = expandDashboardTableWidgetConfigurationInput(v.(map[string]interface{}))
 				if err != nil {
-					return nil, err
+					return nil, nil, err
+				}
+
+				if expandDashboardWidgetLinkedEntityGUIDsInput(&widget, v.(map[string]interface{})) {
+					filterCurrentDashboard[i] = append(filterCurrentDashboard[i], len(page.Widgets))
+				}
+
+				page.Widgets = append(page.Widgets, widget)
+			}
+		}
+		for _, widgetType := range dashboardRawWidgetTypes() {
+			widgets, ok := p[widgetType]
+			if !ok {
+				continue
+			}
+
+			for _, v := range widgets.([]interface{}) {
+				// Get generic properties set
+				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				widget.Visualization.ID = dashboardRawWidgetVisualizations[widgetType]
+				widget.RawConfiguration, err = expandDashboardWidgetRawConfigurationInput(v.(map[string]interface{}))
+				if err != nil {
+					return nil, nil, err
 				}
 
 				page.Widgets = append(page.Widgets, widget)
 			}
 		}
+		if widgets, ok := p["widget_raw"]; ok {
+			for _, v := range widgets.([]interface{}) {
+				// Get generic properties set
+				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				widget.Visualization.ID = v.(map[string]interface{})["visualization_id"].(string)
+				widget.RawConfiguration = entities.DashboardWidgetRawConfiguration(v.(map[string]interface{})["configuration"].(string))
+
+				page.Widgets = append(page.Widgets, widget)
+			}
+		}
 
 		expanded[i] = page
 	}
 
-	return expanded, nil
+	return expanded, filterCurrentDashboard, nil
 }
 
 func expandDashboardAreaWidgetConfigurationInput(i map[string]interface{}) (*dashboards.DashboardAreaWidgetConfigurationInput, error) {
@@ -299,6 +465,44 @@ func expandDashboardTableWidgetConfigurationInput(i map[string]interface{}) (*da
 	return nil, nil
 }
 
+// expandDashboardWidgetRawConfigurationInput expands the configuration of the
+// widgets without a typed configuration
+func expandDashboardWidgetRawConfigurationInput(i map[string]interface{}) (entities.DashboardWidgetRawConfiguration, error) {
+	var cfg dashboardWidgetRawConfiguration
+
+	if q, ok := i["nrql_query"]; ok {
+		queries, err := expandDashboardWidgetNRQLQueryInput(q.([]interface{}))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, query := range queries {
+			cfg.NRQLQueries = append(cfg.NRQLQueries, entities.DashboardWidgetNRQLQuery(query))
+		}
+	}
+
+	if l, ok := i["limit"]; ok {
+		cfg.Limit = l.(float64)
+	}
+
+	return json.Marshal(cfg)
+}
+
+// expandDashboardWidgetLinkedEntityGUIDsInput sets the entities linked to a
+// widget, and returns whether the widget filters the current dashboard. The
+// widget is linked to its own page for that, once the page GUID is known.
+func expandDashboardWidgetLinkedEntityGUIDsInput(widget *dashboards.DashboardWidgetInput, w map[string]interface{}) bool {
+	if guids, ok := w["linked_entity_guids"]; ok {
+		for _, guid := range guids.([]interface{}) {
+			widget.LinkedEntityGUIDs = append(widget.LinkedEntityGUIDs, entities.EntityGUID(guid.(string)))
+		}
+	}
+
+	filter, _ := w["filter_current_dashboard"].(bool)
+
+	return filter
+}
+
 // expandDashboardWidgetInput expands the common items in WidgetInput, but not the configuration
 // which is specific to the widgets
 func expandDashboardWidgetInput(w map[string]interface{}) (dashboards.DashboardWidgetInput, error) {
@@ -354,7 +558,7 @@ func expandDashboardWidgetNRQLQueryInput(queries []interface{}) ([]dashboards.Da
 // Unpack the *dashboards.Dashboard variable and set resource data.
 //
 // Used by the newrelic_dashboard Read function (resourceNewRelicDashboardRead)
-func flattenDashboardEntity(dashboard *entities.DashboardEntity, d *schema.ResourceData) error {
+func flattenDashboardEntity(dashboard *entities.DashboardEntity, variables []dashboardVariable, d *schema.ResourceData) error {
 	d.Set("account_id", dashboard.AccountID)
 	d.Set("guid", dashboard.GUID)
 	d.Set("name", dashboard.Name)
@@ -367,23 +571,25 @@ func flattenDashboardEntity(dashboard *entities.DashboardEntity, d *schema.Resou
 
 	if dashboard.Pages != nil && len(dashboard.Pages) > 0 {
 		pages := flattenDashboardPage(&dashboard.Pages)
+		flattenDashboardRawWidgetsConfigured(pages, d)
 		if err := d.Set("page", pages); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return d.Set("variable", flattenDashboardVariables(variables))
 }
 
 // Unpack the *dashboards.Dashboard variable and set resource data.
 //
 // Used by the newrelic_dashboard Read function (resourceNewRelicDashboardRead)
-func flattenDashboardUpdateResult(result *dashboards.DashboardUpdateResult, d *schema.ResourceData) error {
+func flattenDashboardUpdateResult(result *dashboardEntityResult, d *schema.ResourceData) error {
 	if result == nil {
 		return fmt.Errorf("can not flatten nil DashboardUpdateResult")
 	}
 
-	dashboard := result.EntityResult // dashboard.DashboardEntityResult
+	dashboard := result.DashboardEntityResult
+	variables := result.Variables
 
 	d.Set("account_id", dashboard.AccountID)
 	d.Set("guid", dashboard.GUID)
@@ -397,12 +603,53 @@ func flattenDashboardUpdateResult(result *dashboards.DashboardUpdateResult, d *s
 
 	if dashboard.Pages != nil && len(dashboard.Pages) > 0 {
 		pages := flattenDashboardPage(&dashboard.Pages)
+		flattenDashboardRawWidgetsConfigured(pages, d)
 		if err := d.Set("page", pages); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return d.Set("variable", flattenDashboardVariables(variables))
+}
+
+func flattenDashboardVariables(in []dashboardVariable) []interface{} {
+	out := make([]interface{}, len(in))
+
+	for i, v := range in {
+		m := make(map[string]interface{})
+
+		m["name"] = v.Name
+		m["title"] = v.Title
+		m["type"] = strings.ToLower(v.Type)
+		m["is_multi_selection"] = v.IsMultiSelection
+		m["replacement_strategy"] = strings.ToLower(v.ReplacementStrategy)
+
+		defaultValues := make([]interface{}, len(v.DefaultValues))
+		for j, item := range v.DefaultValues {
+			defaultValues[j] = item.Value.String
+		}
+		m["default_values"] = defaultValues
+
+		items := make([]interface{}, len(v.Items))
+		for j, item := range v.Items {
+			items[j] = map[string]interface{}{
+				"title": item.Title,
+				"value": item.Value,
+			}
+		}
+		m["item"] = items
+
+		if v.NRQLQuery != nil {
+			m["nrql_query"] = []interface{}{map[string]interface{}{
+				"account_ids": v.NRQLQuery.AccountIDs,
+				"query":       v.NRQLQuery.Query,
+			}}
+		}
+
+		out[i] = m
+	}
+
+	return out
 }
 
 // return []interface{} because Page is a SetList
@@ -425,32 +672,17 @@ func flattenDashboardPage(in *[]entities.DashboardPage) []interface{} {
 		m["widget_line"] = []interface{}{}
 		m["widget_markdown"] = []interface{}{}
 		m["widget_pie"] = []interface{}{}
+		m["widget_raw"] = []interface{}{}
 		m["widget_table"] = []interface{}{}
+		for _, widgetType := range dashboardRawWidgetTypes() {
+			m[widgetType] = []interface{}{}
+		}
 
 		for _, widget := range p.Widgets {
-			var widgetType string
-			w := flattenDashboardWidget(&widget)
-
-			switch widget.Visualization.ID {
-			case "viz.area":
-				widgetType = "widget_area"
-			case "viz.bar":
-				widgetType = "widget_bar"
-			case "viz.billboard":
-				widgetType = "widget_billboard"
-			case "viz.line":
-				widgetType = "widget_line"
-			case "viz.markdown":
-				widgetType = "widget_markdown"
-			case "viz.pie":
-				widgetType = "widget_pie"
-			case "viz.table":
-				widgetType = "widget_table"
-			}
+			w := flattenDashboardWidget(&widget, p.GUID)
+			widgetType := dashboardWidgetType(widget.Visualization.ID)
 
-			if widgetType != "" {
-				m[widgetType] = append(m[widgetType].([]interface{}), w)
-			}
+			m[widgetType] = append(m[widgetType].([]interface{}), w)
 		}
 
 		out[i] = m
@@ -459,7 +691,7 @@ func flattenDashboardPage(in *[]entities.DashboardPage) []interface{} {
 	return out
 }
 
-func flattenDashboardWidget(in *entities.DashboardWidget) map[string]interface{} {
+func flattenDashboardWidget(in *entities.DashboardWidget, pageGUID entities.EntityGUID) map[string]interface{} {
 	out := make(map[string]interface{})
 
 	out["id"] = in.ID
@@ -480,6 +712,7 @@ func flattenDashboardWidget(in *entities.DashboardWidget) map[string]interface{}
 		if len(in.Configuration.Bar.NRQLQueries) > 0 {
 			out["nrql_query"] = flattenDashboardWidgetNRQLQuery(&in.Configuration.Bar.NRQLQueries)
 		}
+		flattenDashboardWidgetLinkedEntities(in, pageGUID, out)
 	case "viz.billboard":
 		if len(in.Configuration.Billboard.NRQLQueries) > 0 {
 			out["nrql_query"] = flattenDashboardWidgetNRQLQuery(&in.Configuration.Billboard.NRQLQueries)
@@ -498,6 +731,7 @@ func flattenDashboardWidget(in *entities.DashboardWidget) map[string]interface{}
 		if len(in.Configuration.Line.NRQLQueries) > 0 {
 			out["nrql_query"] = flattenDashboardWidgetNRQLQuery(&in.Configuration.Line.NRQLQueries)
 		}
+		flattenDashboardWidgetLinkedEntities(in, pageGUID, out)
 	case "viz.markdown":
 		if in.Configuration.Markdown.Text != "" {
 			out["text"] = in.Configuration.Markdown.Text
@@ -506,15 +740,84 @@ func flattenDashboardWidget(in *entities.DashboardWidget) map[string]interface{}
 		if len(in.Configuration.Pie.NRQLQueries) > 0 {
 			out["nrql_query"] = flattenDashboardWidgetNRQLQuery(&in.Configuration.Pie.NRQLQueries)
 		}
+		flattenDashboardWidgetLinkedEntities(in, pageGUID, out)
 	case "viz.table":
 		if len(in.Configuration.Table.NRQLQueries) > 0 {
 			out["nrql_query"] = flattenDashboardWidgetNRQLQuery(&in.Configuration.Table.NRQLQueries)
 		}
+		flattenDashboardWidgetLinkedEntities(in, pageGUID, out)
+	default:
+		if dashboardRawWidgetType(in.Visualization.ID) == "" {
+			out["visualization_id"] = in.Visualization.ID
+			out["configuration"], _ = structure.NormalizeJsonString(string(in.RawConfiguration))
+		} else if len(in.RawConfiguration) > 0 {
+			flattenDashboardWidgetRawConfiguration(in, out)
+		}
 	}
 
 	return out
 }
 
+// flattenDashboardRawWidgetsConfigured leaves the keys that are not in the
+// configured configuration of raw widgets out of the configuration read back,
+// so that the defaults New Relic adds, such as platformOptions, are not seen
+// as changes.
+func flattenDashboardRawWidgetsConfigured(pages []interface{}, d *schema.ResourceData) {
+	for i, page := range pages {
+		for j, widget := range page.(map[string]interface{})["widget_raw"].([]interface{}) {
+			w := widget.(map[string]interface{})
+
+			configured, ok := d.Get(fmt.Sprintf("page.%d.widget_raw.%d.configuration", i, j)).(string)
+			if !ok || configured == "" {
+				continue
+			}
+
+			if configuration, err := filterDashboardConfiguredJSON(w["configuration"].(string), configured); err == nil {
+				w["configuration"] = configuration
+			}
+		}
+	}
+}
+
+// flattenDashboardWidgetLinkedEntities flattens the entities linked to a
+// widget. A link to the page of the widget filters the current dashboard.
+func flattenDashboardWidgetLinkedEntities(in *entities.DashboardWidget, pageGUID entities.EntityGUID, out map[string]interface{}) {
+	filterCurrentDashboard := false
+	linkedEntityGUIDs := []interface{}{}
+
+	for _, entity := range in.LinkedEntities {
+		guid := entity.GetGUID()
+
+		if guid == pageGUID {
+			filterCurrentDashboard = true
+		} else {
+			linkedEntityGUIDs = append(linkedEntityGUIDs, string(guid))
+		}
+	}
+
+	out["filter_current_dashboard"] = filterCurrentDashboard
+	out["linked_entity_guids"] = linkedEntityGUIDs
+}
+
+// flattenDashboardWidgetRawConfiguration flattens the configuration of the
+// widgets without a typed configuration
+func flattenDashboardWidgetRawConfiguration(in *entities.DashboardWidget, out map[string]interface{}) {
+	var cfg dashboardWidgetRawConfiguration
+
+	if err := json.Unmarshal(in.RawConfiguration, &cfg); err != nil {
+		log.Printf("[WARN] Error reading the configuration of widget %s: %s", in.ID, err)
+		return
+	}
+
+	if len(cfg.NRQLQueries) > 0 {
+		out["nrql_query"] = flattenDashboardWidgetNRQLQuery(&cfg.NRQLQueries)
+	}
+
+	if in.Visualization.ID == dashboardRawWidgetVisualizations["widget_bullet"] {
+		out["limit"] = cfg.Limit
+	}
+}
+
 func flattenDashboardWidgetNRQLQuery(in *[]entities.DashboardWidgetNRQLQuery) []interface{} {
 	out := make([]interface{}, len(*in))
 
@@ -0,0 +1,236 @@
+package newrelic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
+	"github.com/newrelic/newrelic-client-go/pkg/entities"
+)
+
+// dashboardJSON is the projection of a dashboard that is compared with the
+// configured JSON to detect the changes made outside of Terraform. It holds the
+// attributes of the JSON exported from the UI, without the IDs and links that
+// New Relic assigns.
+type dashboardJSON struct {
+	Name        string                        `json:"name"`
+	Description string                        `json:"description,omitempty"`
+	Permissions entities.DashboardPermissions `json:"permissions"`
+	Pages       []dashboardJSONPage           `json:"pages"`
+	Variables   []dashboardVariable           `json:"variables"`
+}
+
+type dashboardJSONPage struct {
+	Name        string                `json:"name"`
+	Description string                `json:"description,omitempty"`
+	Widgets     []dashboardJSONWidget `json:"widgets"`
+}
+
+type dashboardJSONWidget struct {
+	Title            string                                   `json:"title,omitempty"`
+	Layout           entities.DashboardWidgetLayout           `json:"layout"`
+	Visualization    entities.DashboardWidgetVisualization    `json:"visualization"`
+	RawConfiguration entities.DashboardWidgetRawConfiguration `json:"rawConfiguration"`
+}
+
+// expandDashboardJSONInput decodes the dashboard JSON exported from the UI,
+// which has the shape of the input of the dashboard mutations.
+func expandDashboardJSONInput(d *schema.ResourceData, accountID int) (*dashboardInput, error) {
+	var export interface{}
+
+	// Numbers are kept as written, as the raw configurations of widgets are
+	// passed through.
+	decoder := json.NewDecoder(bytes.NewBufferString(d.Get("json").(string)))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&export); err != nil {
+		return nil, fmt.Errorf("invalid dashboard JSON: %s", err)
+	}
+
+	if d.Get("replace_account_ids").(bool) {
+		replaceDashboardJSONAccountIDs(export, accountID)
+	}
+
+	b, err := json.Marshal(export)
+	if err != nil {
+		return nil, err
+	}
+
+	var dashboard dashboardInput
+	if err := json.Unmarshal(b, &dashboard); err != nil {
+		return nil, fmt.Errorf("invalid dashboard JSON: %s", err)
+	}
+
+	if dashboard.Name == "" {
+		return nil, fmt.Errorf("invalid dashboard JSON: name is required")
+	}
+
+	if dashboard.Permissions == "" {
+		dashboard.Permissions = entities.DashboardPermissionsTypes.PUBLIC_READ_ONLY
+	}
+
+	return &dashboard, nil
+}
+
+// replaceDashboardJSONAccountIDs replaces the account IDs of the NRQL queries
+// of a dashboard JSON, in the widgets as well as the variables.
+func replaceDashboardJSONAccountIDs(v interface{}, accountID int) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, item := range value {
+			switch {
+			case k == "accountId" && item != nil:
+				value[k] = accountID
+			case k == "accountIds" && item != nil:
+				value[k] = []interface{}{accountID}
+			default:
+				replaceDashboardJSONAccountIDs(item, accountID)
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			replaceDashboardJSONAccountIDs(item, accountID)
+		}
+	}
+}
+
+// flattenDashboardJSON returns the normalized projection of a dashboard read
+// from New Relic.
+func flattenDashboardJSON(dashboard *entities.DashboardEntity, variables []dashboardVariable) (string, error) {
+	projection := dashboardJSON{
+		Name:        dashboard.Name,
+		Description: dashboard.Description,
+		Permissions: dashboard.Permissions,
+		Pages:       []dashboardJSONPage{},
+		Variables:   append([]dashboardVariable{}, variables...),
+	}
+
+	for _, page := range dashboard.Pages {
+		widgets := []dashboardJSONWidget{}
+		for _, widget := range page.Widgets {
+			widgets = append(widgets, dashboardJSONWidget{
+				Title:            widget.Title,
+				Layout:           widget.Layout,
+				Visualization:    widget.Visualization,
+				RawConfiguration: widget.RawConfiguration,
+			})
+		}
+
+		projection.Pages = append(projection.Pages, dashboardJSONPage{
+			Name:        page.Name,
+			Description: page.Description,
+			Widgets:     widgets,
+		})
+	}
+
+	return normalizeDashboardJSON(projection)
+}
+
+// flattenDashboardJSONInput returns the normalized projection of the dashboard
+// written from the JSON.
+func flattenDashboardJSONInput(input *dashboardInput) (string, error) {
+	projection := dashboardJSON{
+		Name:        input.Name,
+		Description: input.Description,
+		Permissions: input.Permissions,
+		Pages:       []dashboardJSONPage{},
+		Variables:   append([]dashboardVariable{}, input.Variables...),
+	}
+
+	for _, page := range input.Pages {
+		widgets := []dashboardJSONWidget{}
+		for _, widget := range page.Widgets {
+			widgets = append(widgets, dashboardJSONWidget{
+				Title: widget.Title,
+				Layout: entities.DashboardWidgetLayout{
+					Column: widget.Layout.Column,
+					Height: widget.Layout.Height,
+					Row:    widget.Layout.Row,
+					Width:  widget.Layout.Width,
+				},
+				Visualization:    entities.DashboardWidgetVisualization{ID: widget.Visualization.ID},
+				RawConfiguration: widget.RawConfiguration,
+			})
+		}
+
+		projection.Pages = append(projection.Pages, dashboardJSONPage{
+			Name:        page.Name,
+			Description: page.Description,
+			Widgets:     widgets,
+		})
+	}
+
+	return normalizeDashboardJSON(projection)
+}
+
+// normalizeDashboardJSON encodes a projection with the keys of the raw
+// configurations sorted, as they are passed through as written.
+func normalizeDashboardJSON(projection dashboardJSON) (string, error) {
+	b, err := json.Marshal(projection)
+	if err != nil {
+		return "", err
+	}
+
+	return structure.NormalizeJsonString(string(b))
+}
+
+// filterDashboardConfiguredJSON returns a JSON read from New Relic with only
+// the keys that are in the configured JSON, at any depth. Arrays are filtered
+// item by item, keeping the items that are not configured.
+func filterDashboardConfiguredJSON(actual string, configured string) (string, error) {
+	var a, c interface{}
+
+	if err := json.Unmarshal([]byte(actual), &a); err != nil {
+		return "", err
+	}
+
+	if err := json.Unmarshal([]byte(configured), &c); err != nil {
+		return "", err
+	}
+
+	b, err := json.Marshal(filterDashboardConfiguredValue(a, c))
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+func filterDashboardConfiguredValue(actual interface{}, configured interface{}) interface{} {
+	switch a := actual.(type) {
+	case map[string]interface{}:
+		c, ok := configured.(map[string]interface{})
+		if !ok {
+			return actual
+		}
+
+		filtered := make(map[string]interface{}, len(c))
+		for k, v := range c {
+			if item, ok := a[k]; ok {
+				filtered[k] = filterDashboardConfiguredValue(item, v)
+			}
+		}
+
+		return filtered
+	case []interface{}:
+		c, ok := configured.([]interface{})
+		if !ok {
+			return actual
+		}
+
+		filtered := make([]interface{}, len(a))
+		for i, item := range a {
+			if i < len(c) {
+				filtered[i] = filterDashboardConfiguredValue(item, c[i])
+			} else {
+				filtered[i] = item
+			}
+		}
+
+		return filtered
+	}
+
+	return actual
+}
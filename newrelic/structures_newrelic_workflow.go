@@ -0,0 +1,194 @@
+package newrelic
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func expandWorkflowInput(d *schema.ResourceData) workflowInput {
+	input := workflowInput{
+		Name:                      d.Get("name").(string),
+		WorkflowEnabled:           d.Get("enabled").(bool),
+		DestinationsEnabled:       d.Get("destinations_enabled").(bool),
+		EnrichmentsEnabled:        d.Get("enrichments_enabled").(bool),
+		MutingRulesHandling:       d.Get("muting_rules_handling").(string),
+		DestinationConfigurations: expandWorkflowDestinations(d.Get("destination").([]interface{})),
+	}
+
+	if enrichments, ok := d.GetOk("enrichments"); ok {
+		input.Enrichments = expandWorkflowEnrichments(enrichments.([]interface{})[0].(map[string]interface{}))
+	} else {
+		input.Enrichments = &workflowEnrichmentsInput{Nrql: []workflowNrqlEnrichmentInput{}}
+	}
+
+	return input
+}
+
+func expandWorkflowCreateInput(d *schema.ResourceData) *workflowCreateInput {
+	filter := d.Get("issues_filter").([]interface{})[0].(map[string]interface{})
+
+	return &workflowCreateInput{
+		workflowInput: expandWorkflowInput(d),
+		IssuesFilter:  expandWorkflowIssuesFilter(filter),
+	}
+}
+
+func expandWorkflowUpdateInput(d *schema.ResourceData) *workflowUpdateInput {
+	filter := d.Get("issues_filter").([]interface{})[0].(map[string]interface{})
+
+	return &workflowUpdateInput{
+		workflowInput: expandWorkflowInput(d),
+		ID:            d.Id(),
+		IssuesFilter: &workflowUpdatedFilterInput{
+			ID:          filter["filter_id"].(string),
+			FilterInput: *expandWorkflowIssuesFilter(filter),
+		},
+	}
+}
+
+func expandWorkflowIssuesFilter(filter map[string]interface{}) *workflowFilterInput {
+	input := &workflowFilterInput{
+		Name:       filter["name"].(string),
+		Type:       filter["type"].(string),
+		Predicates: []workflowPredicate{},
+	}
+
+	for _, p := range filter["predicate"].([]interface{}) {
+		predicate := p.(map[string]interface{})
+
+		input.Predicates = append(input.Predicates, workflowPredicate{
+			Attribute: predicate["attribute"].(string),
+			Operator:  predicate["operator"].(string),
+			Values:    expandStringList(predicate["values"].([]interface{})),
+		})
+	}
+
+	return input
+}
+
+// expandWorkflowEnrichments keeps the IDs of existing enrichments, so that
+// they are updated rather than replaced.
+func expandWorkflowEnrichments(enrichments map[string]interface{}) *workflowEnrichmentsInput {
+	input := &workflowEnrichmentsInput{Nrql: []workflowNrqlEnrichmentInput{}}
+
+	for _, e := range enrichments["nrql"].([]interface{}) {
+		enrichment := e.(map[string]interface{})
+
+		nrql := workflowNrqlEnrichmentInput{
+			ID:            enrichment["enrichment_id"].(string),
+			Name:          enrichment["name"].(string),
+			Configuration: []workflowNrqlConfiguration{},
+		}
+
+		for _, c := range enrichment["configuration"].([]interface{}) {
+			nrql.Configuration = append(nrql.Configuration, workflowNrqlConfiguration{
+				Query: c.(map[string]interface{})["query"].(string),
+			})
+		}
+
+		input.Nrql = append(input.Nrql, nrql)
+	}
+
+	return input
+}
+
+func expandWorkflowDestinations(destinations []interface{}) []workflowDestinationConfigurationInput {
+	expanded := []workflowDestinationConfigurationInput{}
+
+	for _, d := range destinations {
+		destination := d.(map[string]interface{})
+
+		expanded = append(expanded, workflowDestinationConfigurationInput{
+			ChannelID:            destination["channel_id"].(string),
+			NotificationTriggers: expandStringList(destination["notification_triggers"].([]interface{})),
+		})
+	}
+
+	return expanded
+}
+
+func flattenWorkflow(w *workflow, d *schema.ResourceData) error {
+	d.Set("name", w.Name)
+	d.Set("enabled", w.WorkflowEnabled)
+	d.Set("destinations_enabled", w.DestinationsEnabled)
+	d.Set("enrichments_enabled", w.EnrichmentsEnabled)
+	d.Set("muting_rules_handling", w.MutingRulesHandling)
+
+	if err := d.Set("issues_filter", flattenWorkflowIssuesFilter(w.IssuesFilter)); err != nil {
+		return err
+	}
+
+	if err := d.Set("enrichments", flattenWorkflowEnrichments(w.Enrichments)); err != nil {
+		return err
+	}
+
+	return d.Set("destination", flattenWorkflowDestinations(w.DestinationConfigurations))
+}
+
+func flattenWorkflowIssuesFilter(filter *workflowFilter) []interface{} {
+	if filter == nil {
+		return nil
+	}
+
+	predicates := make([]interface{}, 0, len(filter.Predicates))
+	for _, p := range filter.Predicates {
+		predicates = append(predicates, map[string]interface{}{
+			"attribute": p.Attribute,
+			"operator":  p.Operator,
+			"values":    p.Values,
+		})
+	}
+
+	return []interface{}{map[string]interface{}{
+		"filter_id": filter.ID,
+		"name":      filter.Name,
+		"type":      filter.Type,
+		"predicate": predicates,
+	}}
+}
+
+// flattenWorkflowEnrichments keeps the NRQL enrichments of a workflow.
+func flattenWorkflowEnrichments(enrichments []workflowEnrichment) []interface{} {
+	nrql := []interface{}{}
+
+	for _, e := range enrichments {
+		if e.Type != "" && e.Type != "NRQL" {
+			continue
+		}
+
+		configurations := make([]interface{}, 0, len(e.Configurations))
+		for _, c := range e.Configurations {
+			configurations = append(configurations, map[string]interface{}{
+				"query": c.Query,
+			})
+		}
+
+		nrql = append(nrql, map[string]interface{}{
+			"enrichment_id": e.ID,
+			"name":          e.Name,
+			"configuration": configurations,
+		})
+	}
+
+	if len(nrql) == 0 {
+		return nil
+	}
+
+	return []interface{}{map[string]interface{}{
+		"nrql": nrql,
+	}}
+}
+
+func flattenWorkflowDestinations(destinations []workflowDestinationConfiguration) []interface{} {
+	flattened := make([]interface{}, 0, len(destinations))
+
+	for _, d := range destinations {
+		flattened = append(flattened, map[string]interface{}{
+			"channel_id":            d.ChannelID,
+			"name":                  d.Name,
+			"type":                  d.Type,
+			"notification_triggers": d.NotificationTriggers,
+		})
+	}
+
+	return flattened
+}
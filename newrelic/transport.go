@@ -0,0 +1,251 @@
+package newrelic
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMaxRetries   = 3
+	defaultRetryWaitMin = 1 * time.Second
+	defaultRetryWaitMax = 30 * time.Second
+)
+
+// The transport below retries the requests to the New Relic APIs with the
+// retry settings of the provider. newrelic-client-go also retries a request
+// that still fails up to 3 times, on the same errors, and the version the
+// provider uses has no option to disable it.
+
+// retryTransport retries the requests that fail to connect, are rate limited
+// or hit a server error, like newrelic-client-go does. The wait between
+// attempts grows exponentially from waitMin to waitMax, unless the response
+// carries a Retry-After header.
+type retryTransport struct {
+	transport  http.RoundTripper
+	maxRetries int
+	waitMin    time.Duration
+	waitMax    time.Duration
+
+	// idempotentOnly restricts the retries of the errors that may happen once
+	// the API has processed a request to the idempotent requests.
+	idempotentOnly bool
+}
+
+// newRetryTransport wraps the given transport with retries. newrelic-client-go
+// only accepts an *http.Transport and silently ignores any other RoundTripper,
+// so the retrying transport is registered as the handler for both schemes of
+// an otherwise empty *http.Transport.
+func newRetryTransport(t http.RoundTripper, maxRetries int, waitMin time.Duration, waitMax time.Duration, idempotentOnly bool) *http.Transport {
+	rt := &retryTransport{
+		transport:      t,
+		maxRetries:     maxRetries,
+		waitMin:        waitMin,
+		waitMax:        waitMax,
+		idempotentOnly: idempotentOnly,
+	}
+
+	wrapper := &http.Transport{}
+	wrapper.RegisterProtocol("http", rt)
+	wrapper.RegisterProtocol("https", rt)
+
+	return wrapper
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The body has to be replayed for every attempt.
+	if req.Body != nil && req.GetBody == nil {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		req = req.Clone(req.Context())
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	idempotent, err := isIdempotentRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		r := req
+
+		if attempt > 0 && req.GetBody != nil {
+			b, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			r = req.Clone(req.Context())
+			r.Body = b
+		}
+
+		resp, err := t.transport.RoundTrip(r)
+
+		if attempt >= t.maxRetries || req.Context().Err() != nil || !retryPolicy(resp, err, idempotent || !t.idempotentOnly) {
+			return resp, err
+		}
+
+		wait := retryBackoff(t.waitMin, t.waitMax, attempt, resp)
+
+		if resp != nil {
+			log.Printf("[DEBUG] %s %s returned %d, retrying in %s (%d/%d)", req.Method, req.URL.Path, resp.StatusCode, wait, attempt+1, t.maxRetries)
+
+			// Drain the body so the connection can be reused.
+			_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+		} else {
+			log.Printf("[DEBUG] %s %s failed: %s, retrying in %s (%d/%d)", req.Method, req.URL.Path, err, wait, attempt+1, t.maxRetries)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// isIdempotentRequest returns whether a request can be sent again once it has
+// reached the API: the requests other than POST and PATCH, and the NerdGraph
+// queries. The body is read from GetBody.
+func isIdempotentRequest(req *http.Request) (bool, error) {
+	if req.Method != http.MethodPost && req.Method != http.MethodPatch {
+		return true, nil
+	}
+
+	if !strings.HasSuffix(req.URL.Path, "/graphql") || req.GetBody == nil {
+		return false, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return false, err
+	}
+	defer body.Close()
+
+	b, err := ioutil.ReadAll(body)
+	if err != nil {
+		return false, err
+	}
+
+	return isGraphQLQuery(b), nil
+}
+
+// isGraphQLQuery returns whether a NerdGraph request body holds a query
+// rather than a mutation.
+func isGraphQLQuery(body []byte) bool {
+	var req struct {
+		Query string `json:"query"`
+	}
+
+	if err := json.Unmarshal(body, &req); err != nil {
+		return false
+	}
+
+	return !strings.HasPrefix(strings.TrimSpace(req.Query), "mutation")
+}
+
+// retryPolicy returns whether a failed attempt is retried. It retries the same
+// errors as newrelic-client-go: the transport errors other than certificate
+// errors, and the 429, 500 and 502 and above responses. The errors that may
+// happen once the API has processed the request are only retried when
+// processedRetryable is true; a request that could not connect or was rate
+// limited is always retried.
+func retryPolicy(resp *http.Response, err error, processedRetryable bool) bool {
+	if err != nil {
+		// Certificate problems won't resolve themselves.
+		var unknownAuthority x509.UnknownAuthorityError
+		var invalidCert x509.CertificateInvalidError
+		var hostname x509.HostnameError
+
+		if errors.As(err, &unknownAuthority) || errors.As(err, &invalidCert) || errors.As(err, &hostname) {
+			return false
+		}
+
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return true
+		}
+
+		return processedRetryable
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true
+	case resp.StatusCode == 0,
+		resp.StatusCode == http.StatusInternalServerError,
+		resp.StatusCode >= http.StatusBadGateway:
+		return processedRetryable
+	}
+
+	return false
+}
+
+// retryBackoff returns how long to wait before the next attempt. The wait
+// grows exponentially from waitMin to waitMax, unless the API sent a
+// Retry-After header, which is honored up to waitMax.
+func retryBackoff(waitMin time.Duration, waitMax time.Duration, attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > waitMax {
+				wait = waitMax
+			}
+
+			return wait
+		}
+	}
+
+	wait := time.Duration(math.Pow(2, float64(attempt)) * float64(waitMin))
+	if wait > waitMax || wait <= 0 {
+		wait = waitMax
+	}
+
+	return wait
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an
+// HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+
+		return wait, true
+	}
+
+	return 0, false
+}
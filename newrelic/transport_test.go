@@ -0,0 +1,255 @@
+// +build unit
+
+package newrelic
+
+import (
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	nr "github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRetryClient returns a client of the given server with fast retries.
+func testRetryClient(t *testing.T, server *httptest.Server, maxRetries int) *nr.NewRelic {
+	cfg := Config{
+		PersonalAPIKey:  "NRAK-TEST",
+		Region:          "US",
+		APIURL:          server.URL,
+		NerdGraphAPIURL: server.URL + "/graphql",
+		MaxRetries:      maxRetries,
+		RetryWaitMin:    time.Millisecond,
+		RetryWaitMax:    5 * time.Millisecond,
+		userAgent:       "terraform-provider-newrelic/test",
+	}
+
+	client, err := cfg.Client()
+	require.NoError(t, err)
+
+	return client
+}
+
+// testRetryTransportClient returns an HTTP client with the retry transport
+// alone, without the retries of newrelic-client-go.
+func testRetryTransportClient(maxRetries int, idempotentOnly bool) *http.Client {
+	return &http.Client{Transport: newRetryTransport(http.DefaultTransport, maxRetries, time.Millisecond, 5*time.Millisecond, idempotentOnly)}
+}
+
+func TestRetryTransport_RetriesQueries(t *testing.T) {
+	var attempts int32
+	var bodies []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		switch atomic.AddInt32(&attempts, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"data":{}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := testRetryClient(t, server, 3)
+
+	var resp struct{}
+	err := client.NerdGraph.QueryWithResponse(`query { actor { user { id } } }`, nil, &resp)
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+	require.Len(t, bodies, 3)
+	assert.Equal(t, bodies[0], bodies[2], "the body must be replayed")
+}
+
+func TestRetryTransport_RetriesMutationsAfterServerErrors(t *testing.T) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&attempts, 1) {
+		case 1:
+			w.WriteHeader(http.StatusInternalServerError)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"data":{}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := testRetryClient(t, server, 3)
+
+	var resp struct{}
+	err := client.NerdGraph.QueryWithResponse(`mutation { alertsPolicyDelete(accountId: 1, id: 1) { id } }`, nil, &resp)
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func TestRetryTransport_IdempotentOnly(t *testing.T) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := testRetryTransportClient(3, true)
+
+	resp, err := client.Post(server.URL+"/graphql", "application/json", strings.NewReader(`{"query":"mutation { alertsPolicyDelete(accountId: 1, id: 1) { id } }"}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts), "only the rate limited attempt is retried")
+
+	atomic.StoreInt32(&attempts, 0)
+
+	resp, err = client.Post(server.URL+"/graphql", "application/json", strings.NewReader(`{"query":"{ actor { user { id } } }"}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, int32(4), atomic.LoadInt32(&attempts), "queries are retried")
+}
+
+func TestRetryTransport_GivesUpAfterMaxRetries(t *testing.T) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusGatewayTimeout)
+	}))
+	defer server.Close()
+
+	resp, err := testRetryTransportClient(2, false).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusGatewayTimeout, resp.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+
+	atomic.StoreInt32(&attempts, 0)
+
+	resp, err = testRetryTransportClient(0, false).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts), "max_retries = 0 disables the retries")
+}
+
+func TestRetryPolicy(t *testing.T) {
+	cases := map[string]struct {
+		resp               *http.Response
+		err                error
+		processedRetryable bool
+		expected           bool
+	}{
+		"rate limited":                     {resp: &http.Response{StatusCode: http.StatusTooManyRequests}, expected: true},
+		"unavailable":                      {resp: &http.Response{StatusCode: http.StatusServiceUnavailable}, processedRetryable: true, expected: true},
+		"unavailable, not idempotent":      {resp: &http.Response{StatusCode: http.StatusServiceUnavailable}},
+		"server error":                     {resp: &http.Response{StatusCode: http.StatusInternalServerError}, processedRetryable: true, expected: true},
+		"not implemented":                  {resp: &http.Response{StatusCode: http.StatusNotImplemented}, processedRetryable: true},
+		"client error":                     {resp: &http.Response{StatusCode: http.StatusBadRequest}, processedRetryable: true},
+		"connection refused":               {err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, expected: true},
+		"connection reset":                 {err: &net.OpError{Op: "read", Err: errors.New("connection reset by peer")}, processedRetryable: true, expected: true},
+		"connection reset, not idempotent": {err: &net.OpError{Op: "read", Err: errors.New("connection reset by peer")}},
+		"certificate not valid":            {err: x509.UnknownAuthorityError{}, processedRetryable: true},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, c.expected, retryPolicy(c.resp, c.err, c.processedRetryable))
+		})
+	}
+}
+
+func TestIsIdempotentRequest(t *testing.T) {
+	cases := map[string]struct {
+		method   string
+		path     string
+		body     string
+		expected bool
+	}{
+		"read":               {method: http.MethodGet, path: "/v2/alerts_policies.json", expected: true},
+		"delete":             {method: http.MethodDelete, path: "/v2/alerts_policies/1.json", expected: true},
+		"create":             {method: http.MethodPost, path: "/v2/alerts_policies.json", body: `{"policy":{}}`},
+		"NerdGraph query":    {method: http.MethodPost, path: "/graphql", body: `{"query":"{ actor { user { id } } }"}`, expected: true},
+		"NerdGraph mutation": {method: http.MethodPost, path: "/graphql", body: `{"query":"mutation { alertsPolicyDelete(accountId: 1, id: 1) { id } }"}`},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest(c.method, "https://api.newrelic.com"+c.path, strings.NewReader(c.body))
+			require.NoError(t, err)
+
+			idempotent, err := isIdempotentRequest(req)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, idempotent)
+		})
+	}
+}
+
+func TestIsGraphQLQuery(t *testing.T) {
+	assert.True(t, isGraphQLQuery([]byte(`{"query":"query($id: Int!) { actor { account(id: $id) { id } } }"}`)))
+	assert.True(t, isGraphQLQuery([]byte(`{"query":"{ actor { user { id } } }"}`)))
+	assert.False(t, isGraphQLQuery([]byte(`{"query":"\n\tmutation { alertsPolicyDelete(accountId: 1, id: 1) { id } }"}`)))
+	assert.False(t, isGraphQLQuery([]byte(`not json`)))
+}
+
+func TestRetryBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, retryBackoff(time.Second, 5*time.Second, 0, nil))
+	assert.Equal(t, 2*time.Second, retryBackoff(time.Second, 5*time.Second, 1, nil))
+	assert.Equal(t, 4*time.Second, retryBackoff(time.Second, 5*time.Second, 2, nil))
+	assert.Equal(t, 5*time.Second, retryBackoff(time.Second, 5*time.Second, 3, nil))
+	assert.Equal(t, 5*time.Second, retryBackoff(time.Second, 5*time.Second, 100, nil))
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", "3")
+	assert.Equal(t, 3*time.Second, retryBackoff(time.Second, 5*time.Second, 0, resp))
+
+	resp.Header.Set("Retry-After", "3600")
+	assert.Equal(t, 5*time.Second, retryBackoff(time.Second, 5*time.Second, 0, resp), "the Retry-After wait is capped")
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	wait, ok := parseRetryAfter("7")
+	assert.True(t, ok)
+	assert.Equal(t, 7*time.Second, wait)
+
+	wait, ok = parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), wait)
+
+	wait, ok = parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.True(t, wait > 59*time.Minute)
+
+	_, ok = parseRetryAfter("")
+	assert.False(t, ok)
+
+	_, ok = parseRetryAfter("-1")
+	assert.False(t, ok)
+
+	_, ok = parseRetryAfter("soon")
+	assert.False(t, ok)
+}
@@ -1,7 +1,12 @@
 package newrelic
 
 import (
+	"encoding/json"
 	"fmt"
+	"time"
+	// The time zones are embedded, as the hosts running Terraform may not have
+	// the tzdata that time.LoadLocation reads otherwise.
+	_ "time/tzdata"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
@@ -60,3 +65,65 @@ func float64AtLeast(min float64) schema.SchemaValidateFunc {
 		return
 	}
 }
+
+// naiveDateTimeFormat is the ISO-8601 layout, without a time zone offset, that
+// NerdGraph expects for muting rule schedule timestamps.
+const naiveDateTimeFormat = "2006-01-02T15:04:05"
+
+// validateNaiveDateTime tests if the provided value is a string holding an
+// ISO-8601 timestamp without a time zone offset, e.g. 2021-01-28T15:30:00
+func validateNaiveDateTime(i interface{}, k string) (s []string, es []error) {
+	v, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if _, err := time.Parse(naiveDateTimeFormat, v); err != nil {
+		es = append(es, fmt.Errorf("expected %s to be a timestamp in the format YYYY-MM-DDThh:mm:ss, got %s", k, v))
+		return
+	}
+
+	return
+}
+
+// validateTimeZone tests if the provided value is a string holding a time
+// zone name from the IANA Time Zone database, e.g. America/Los_Angeles
+func validateTimeZone(i interface{}, k string) (s []string, es []error) {
+	v, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if v == "" {
+		es = append(es, fmt.Errorf("expected %s to be a valid time zone, got an empty string", k))
+		return
+	}
+
+	if _, err := time.LoadLocation(v); err != nil {
+		es = append(es, fmt.Errorf("expected %s to be a valid IANA time zone, got %s", k, v))
+		return
+	}
+
+	return
+}
+
+// validateJSONObject tests if the provided value is a string holding a JSON
+// object, e.g. {"key": "value"}. The value is left out of the error, as it is
+// used on sensitive attributes.
+func validateJSONObject(i interface{}, k string) (s []string, es []error) {
+	v, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(v), &object); err != nil {
+		es = append(es, fmt.Errorf("expected %s to be a JSON object: %s", k, err))
+		return
+	}
+
+	return
+}
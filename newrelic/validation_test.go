@@ -4,6 +4,7 @@ package newrelic
 
 import (
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -80,6 +81,89 @@ func TestValidationFloat64AtLeast(t *testing.T) {
 	})
 }
 
+func TestValidationNaiveDateTime(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			val: "2021-01-28T15:30:00",
+			f:   validateNaiveDateTime,
+		},
+		{
+			val:         "2021-01-28T15:30:00-08:00",
+			f:           validateNaiveDateTime,
+			expectedErr: regexp.MustCompile(`expected [\w]+ to be a timestamp in the format YYYY-MM-DDThh:mm:ss`),
+		},
+		{
+			val:         "2021-01-28",
+			f:           validateNaiveDateTime,
+			expectedErr: regexp.MustCompile(`expected [\w]+ to be a timestamp in the format YYYY-MM-DDThh:mm:ss`),
+		},
+		{
+			val:         1,
+			f:           validateNaiveDateTime,
+			expectedErr: regexp.MustCompile(`expected type of [\w]+ to be string`),
+		},
+	})
+}
+
+func TestValidationTimeZone(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			val: "America/Los_Angeles",
+			f:   validateTimeZone,
+		},
+		{
+			val: "UTC",
+			f:   validateTimeZone,
+		},
+		{
+			val:         "Mars/Olympus_Mons",
+			f:           validateTimeZone,
+			expectedErr: regexp.MustCompile(`expected [\w]+ to be a valid IANA time zone, got Mars/Olympus_Mons`),
+		},
+		{
+			val:         "",
+			f:           validateTimeZone,
+			expectedErr: regexp.MustCompile(`expected [\w]+ to be a valid time zone`),
+		},
+	})
+}
+
+func TestValidationJSONObject(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			val: `{"condition_name": "$CONDITION_NAME", "nested": {"key": 1}}`,
+			f:   validateJSONObject,
+		},
+		{
+			val:         `{"condition_name": "$CONDITION_NAME",}`,
+			f:           validateJSONObject,
+			expectedErr: regexp.MustCompile(`expected [\w]+ to be a JSON object`),
+		},
+		{
+			val:         `["$CONDITION_NAME"]`,
+			f:           validateJSONObject,
+			expectedErr: regexp.MustCompile(`expected [\w]+ to be a JSON object`),
+		},
+		{
+			val:         1,
+			f:           validateJSONObject,
+			expectedErr: regexp.MustCompile(`expected type of [\w]+ to be string`),
+		},
+	})
+}
+
+func TestValidationJSONObject_OmitsValue(t *testing.T) {
+	_, errs := validateJSONObject(`{"token": "NRAK-SECRET",}`, "payload_string")
+
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %d", len(errs))
+	}
+
+	if strings.Contains(errs[0].Error(), "NRAK-SECRET") {
+		t.Fatalf("expected the error to leave out the value, got %s", errs[0])
+	}
+}
+
 func runTestCases(t *testing.T, cases []testCase) {
 	matchErr := func(errs []error, r *regexp.Regexp) bool {
 		// err must match one provided